@@ -2,27 +2,54 @@ package kinit
 
 import (
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
 )
 
 // Arena represents an objects holder.
+//
+// Arena is safe for concurrent use by multiple goroutines.
 type Arena struct {
 	// parents specifies parent arenas.
 	parents []*Arena
+	// mutex specifies the mutex that guards fields below.
+	mutex sync.Mutex
 	// objects specifies registered objects.
 	objects map[reflect.Type]reflect.Value
+	// pending specifies objects that are being created for registration on this arena.
+	pending map[reflect.Type]*pendingObject
 	// reaper specifies the reaper for registered objects.
 	reaper *kdone.Reaper
+	// stoppers specifies objects that must be stopped before the reaper will be finalized.
+	stoppers []Stopper
+	// tracked specifies objects referred by pointers which lifecycle is managed by this arena.
+	tracked map[interface{}]bool
+	// stopTimeout specifies the time limit for stopping each object (zero means no limit).
+	stopTimeout time.Duration
+	// scope specifies the scope of objects registered on this arena by the container.
+	scope Scope
 	// finalized specifies whether were registered objects destroyed.
 	finalized bool
 }
 
+// pendingObject represents an object that is being created.
+type pendingObject struct {
+	// done specifies the channel that will be closed when the creation ends.
+	done chan struct{}
+	// obj specifies the created object.
+	obj reflect.Value
+	// err specifies the error occurred on creation.
+	err error
+}
+
 // NewArena returns a new arena with given parent arenas.
 func NewArena(parents ...*Arena) *Arena {
 	a := &Arena{
 		objects: make(map[reflect.Type]reflect.Value),
+		pending: make(map[reflect.Type]*pendingObject),
 		reaper:  kdone.NewReaper(),
 	}
 	if len(parents) > 0 {
@@ -37,6 +64,8 @@ func (a *Arena) Put(t reflect.Type, obj reflect.Value, dtor kdone.Destructor) er
 	if a == nil {
 		return kerror.New(kerror.ENil, "nil arena cannot register object")
 	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 	if a.finalized {
 		return kerror.New(kerror.EIllegal, "arena has already destroyed objects")
 	}
@@ -53,6 +82,17 @@ func (a *Arena) Put(t reflect.Type, obj reflect.Value, dtor kdone.Destructor) er
 	return nil
 }
 
+// assume passes the responsibility for calling the given destructor to this arena
+// without registering an object.
+func (a *Arena) assume(dtor kdone.Destructor) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.finalized {
+		return kerror.New(kerror.EIllegal, "arena has already destroyed objects")
+	}
+	return a.reaper.Assume(dtor)
+}
+
 // MustPut is a variant of the Put that panics on error.
 func (a *Arena) MustPut(t reflect.Type, obj reflect.Value, dtor kdone.Destructor) {
 	if err := a.Put(t, obj, dtor); err != nil {
@@ -67,7 +107,10 @@ func (a *Arena) Get(t reflect.Type) (obj reflect.Value, ok bool) {
 	if a == nil || t == nil {
 		return reflect.Value{}, false
 	}
-	if obj, ok = a.objects[t]; ok {
+	a.mutex.Lock()
+	obj, ok = a.objects[t]
+	a.mutex.Unlock()
+	if ok {
 		return
 	}
 	for _, parent := range a.parents {
@@ -81,18 +124,166 @@ func (a *Arena) Get(t reflect.Type) (obj reflect.Value, ok bool) {
 	return
 }
 
+// obtain returns an object of the given type like the Get does. If the object is absent
+// it will be created by the given function which must register it on this arena.
+//
+// The function is called at most once for each type even if the object is requested
+// by several goroutines simultaneously: all of them will wait for the creation end.
+// If the object is being created on one of parent arenas at the moment it will be waited
+// for as well instead of creating a duplicate on this arena.
+func (a *Arena) obtain(t reflect.Type, create func() (reflect.Value, error)) (reflect.Value, error) {
+	a.mutex.Lock()
+	if obj, ok := a.objects[t]; ok {
+		a.mutex.Unlock()
+		return obj, nil
+	}
+	if p, ok := a.pending[t]; ok {
+		a.mutex.Unlock()
+		<-p.done
+		return p.obj, p.err
+	}
+	p := &pendingObject{
+		done: make(chan struct{}),
+		// This error will be seen by waiting goroutines only if the creation panics.
+		err: kerror.Newf(kerror.EIllegal, "%s object creation was interrupted", t),
+	}
+	a.pending[t] = p
+	a.mutex.Unlock()
+	defer func() {
+		a.mutex.Lock()
+		delete(a.pending, t)
+		a.mutex.Unlock()
+		close(p.done)
+	}()
+	a.wait(t)
+	if obj, ok := a.Get(t); ok {
+		p.obj, p.err = obj, nil
+		return obj, nil
+	}
+	p.obj, p.err = create()
+	return p.obj, p.err
+}
+
+// wait waits for the end of creations of objects of the given type
+// on non-finalized parent arenas of this one.
+func (a *Arena) wait(t reflect.Type) {
+	for _, parent := range a.parents {
+		if parent == nil || parent.Finalized() {
+			continue
+		}
+		parent.mutex.Lock()
+		p, ok := parent.pending[t]
+		parent.mutex.Unlock()
+		if ok {
+			<-p.done
+		}
+		parent.wait(t)
+	}
+}
+
+// scoped returns the nearest arena of the given scope among this arena and its non-finalized
+// ancestors which are bypassed like the Get does, or nil if there is no such arena.
+//
+// Arenas without parents are considered as arenas of the Singleton scope unless the scope is specified.
+func (a *Arena) scoped(scope Scope) *Arena {
+	if a.scope == scope || (scope == Singleton && a.scope == "" && len(a.parents) == 0) {
+		return a
+	}
+	for _, parent := range a.parents {
+		if parent == nil || parent.Finalized() {
+			continue
+		}
+		if arena := parent.scoped(scope); arena != nil {
+			return arena
+		}
+	}
+	return nil
+}
+
+// track marks the given object as the one which lifecycle is managed by this arena and returns true
+// unless the object is referred by a pointer and was already marked on this arena or on one of its
+// non-finalized ancestors.
+func (a *Arena) track(x interface{}) bool {
+	if reflect.ValueOf(x).Kind() != reflect.Ptr {
+		return true
+	}
+	if a.tracks(x) {
+		return false
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.tracked == nil {
+		a.tracked = make(map[interface{}]bool)
+	}
+	a.tracked[x] = true
+	return true
+}
+
+// untrack removes the mark of the given object made by the track on this arena,
+// thus the object may be started again.
+func (a *Arena) untrack(x interface{}) {
+	if reflect.ValueOf(x).Kind() != reflect.Ptr {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	delete(a.tracked, x)
+}
+
+// tracks returns boolean specifies whether the given object is marked on this arena
+// or on one of its non-finalized ancestors (see the track).
+func (a *Arena) tracks(x interface{}) bool {
+	a.mutex.Lock()
+	ok := a.tracked[x]
+	a.mutex.Unlock()
+	if ok {
+		return true
+	}
+	for _, parent := range a.parents {
+		if parent == nil || parent.Finalized() {
+			continue
+		}
+		if parent.tracks(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// enlist passes the responsibility for stopping the given object to this arena.
+func (a *Arena) enlist(stopper Stopper) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.finalized {
+		return kerror.New(kerror.EIllegal, "arena has already destroyed objects")
+	}
+	a.stoppers = append(a.stoppers, stopper)
+	return nil
+}
+
 // Finalize destroys objects registered on this arena.
+//
+// Objects implementing the Stopper interface are stopped in the reverse order
+// before the destruction of any object.
 func (a *Arena) Finalize() error {
 	if a == nil {
 		return nil
 	}
+	a.mutex.Lock()
 	if a.finalized {
+		a.mutex.Unlock()
 		return kerror.New(kerror.EIllegal, "arena has already destroyed objects")
 	}
-	defer func() {
-		a.finalized = true
-	}()
-	return a.reaper.Finalize()
+	a.finalized = true
+	stoppers := a.stoppers
+	a.stoppers = nil
+	a.mutex.Unlock()
+	coerr := kerror.NewCollector()
+	for i := len(stoppers) - 1; i >= 0; i-- {
+		coerr.Collect(stop(stoppers[i], a.stopTimeout))
+	}
+	coerr.Collect(a.reaper.Finalize())
+	return coerr.Error()
 }
 
 // MustFinalize is a variant of the Finalize that panics on error.
@@ -107,5 +298,7 @@ func (a *Arena) Finalized() bool {
 	if a == nil {
 		return false
 	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 	return a.finalized
 }
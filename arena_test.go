@@ -2,7 +2,10 @@ package kinit
 
 import (
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
@@ -200,6 +203,86 @@ func TestArena_Finalized(t *testing.T) {
 	}
 }
 
+func TestArena_obtain__Concurrent(t *testing.T) {
+	arena := NewArena()
+	defer arena.MustFinalize()
+	xt := reflect.TypeOf(0)
+	var created int32
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj, err := arena.obtain(xt, func() (reflect.Value, error) {
+				atomic.AddInt32(&created, 1)
+				time.Sleep(10 * time.Millisecond)
+				obj := reflect.ValueOf(1)
+				return obj, arena.Put(xt, obj, kdone.Noop)
+			})
+			if err != nil || obj.Interface() != 1 {
+				t.Logf("%+v", err)
+				t.Fail()
+			}
+		}()
+	}
+	wg.Wait()
+	if created != 1 {
+		t.Logf("object was created %d times", created)
+		t.Fail()
+		return
+	}
+}
+
+func TestArena_obtain__PendingOnParent(t *testing.T) {
+	parent := NewArena()
+	defer parent.MustFinalize()
+	xt := reflect.TypeOf(0)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := parent.obtain(xt, func() (reflect.Value, error) {
+			close(started)
+			<-release
+			obj := reflect.ValueOf(1)
+			return obj, parent.Put(xt, obj, kdone.Noop)
+		})
+		if err != nil {
+			t.Logf("%+v", err)
+			t.Fail()
+		}
+	}()
+	<-started
+	var created int32
+	for i := 0; i < 2; i++ {
+		child := NewArena(parent)
+		defer child.MustFinalize()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj, err := child.obtain(xt, func() (reflect.Value, error) {
+				atomic.AddInt32(&created, 1)
+				obj := reflect.ValueOf(2)
+				return obj, child.Put(xt, obj, kdone.Noop)
+			})
+			if err != nil || obj.Interface() != 1 {
+				t.Logf("%+v", err)
+				t.Fail()
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if created != 0 {
+		t.Logf("object was created on child arenas %d times", created)
+		t.Fail()
+		return
+	}
+}
+
 func TestNilArena_Put(t *testing.T) {
 	err := (*Arena)(nil).Put(nil, reflect.Value{}, nil)
 	t.Logf("%+v", err)
@@ -1,7 +1,11 @@
 package kinit
 
 import (
+	"context"
 	"reflect"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
@@ -9,12 +13,25 @@ import (
 
 // Container represents a dependency injection container.
 //
+// Container is safe for concurrent use by multiple goroutines: functors may be run simultaneously
+// while constructors and processors are registered.
+//
 // The usual identifier for variables of this type is ctr.
 type Container struct {
+	// mutex specifies the mutex that guards fields below.
+	mutex sync.RWMutex
 	// constructors specifies registered constructors associated with types of objects they are create.
 	constructors map[reflect.Type]Constructor
 	// processors specifies registered processors associated with types of objects they are process.
 	processors map[reflect.Type][]Processor
+	// groups specifies registered group members associated with types of groups they are contribute to.
+	groups map[reflect.Type][]Member
+	// parallel specifies whether to resolve dependencies concurrently.
+	parallel bool
+	// stopTimeout specifies the time limit for stopping each object (zero means no limit).
+	stopTimeout time.Duration
+	// tracer specifies the tracer to report to (nil means no tracing).
+	tracer Tracer
 }
 
 // NewContainer returns a new dependency injection container.
@@ -22,6 +39,7 @@ func NewContainer() *Container {
 	return &Container{
 		constructors: make(map[reflect.Type]Constructor),
 		processors:   make(map[reflect.Type][]Processor),
+		groups:       make(map[reflect.Type][]Member),
 	}
 }
 
@@ -29,6 +47,69 @@ func NewContainer() *Container {
 //
 // Only one constructor for a type may be registered.
 func (c *Container) Provide(ctor Constructor) error {
+	return c.ProvideAll(ctor)
+}
+
+// MustProvide is a variant of the Provide that panics on error.
+func (c *Container) MustProvide(ctor Constructor) {
+	if err := c.Provide(ctor); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideAll registers given constructors in this container like the Provide does.
+//
+// Either all of them are registered or none: if some of them cannot be registered
+// the error will be returned without registration of others.
+func (c *Container) ProvideAll(ctors ...Constructor) error {
+	if c == nil {
+		return kerror.New(kerror.ENil, "nil container cannot register constructor")
+	}
+	for _, ctor := range ctors {
+		if ctor == nil {
+			return kerror.New(kerror.EInvalid, "container cannot register nil constructor")
+		}
+		if ctor.Type() == nil {
+			return kerror.New(kerror.EInvalid, "container cannot register constructor for nil type")
+		}
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i, ctor := range ctors {
+		t := ctor.Type()
+		existing, ok := c.constructors[t]
+		for _, prev := range ctors[:i] {
+			if prev.Type() == t {
+				existing, ok = prev, true
+			}
+		}
+		if ok {
+			return kerror.Newf(kerror.EAmbiguous, "%s constructor already registered%s%s",
+				t, describe(existing), conflicting(ctor))
+		}
+		if _, ok := c.groups[t]; ok {
+			return kerror.Newf(kerror.EAmbiguous, "%s group already registered%s", t, conflicting(ctor))
+		}
+	}
+	for _, ctor := range ctors {
+		c.constructors[ctor.Type()] = ctor
+	}
+	return nil
+}
+
+// MustProvideAll is a variant of the ProvideAll that panics on error.
+func (c *Container) MustProvideAll(ctors ...Constructor) {
+	if err := c.ProvideAll(ctors...); err != nil {
+		panic(err)
+	}
+}
+
+// Override registers the given constructor in this container replacing a constructor
+// previously registered for the same type if any.
+//
+// This method is intended for tests that need to substitute some objects (e.g. with fakes)
+// in a container that was cloned from the global one.
+func (c *Container) Override(ctor Constructor) error {
 	if c == nil {
 		return kerror.New(kerror.ENil, "nil container cannot register constructor")
 	}
@@ -39,23 +120,177 @@ func (c *Container) Provide(ctor Constructor) error {
 	if t == nil {
 		return kerror.New(kerror.EInvalid, "container cannot register constructor for nil type")
 	}
-	if _, ok := c.constructors[t]; ok {
-		return kerror.Newf(kerror.EAmbiguous, "%s constructor already registered", t)
-	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.groups, t)
 	c.constructors[t] = ctor
 	return nil
 }
 
-// MustProvide is a variant of the Provide that panics on error.
-func (c *Container) MustProvide(ctor Constructor) {
-	if err := c.Provide(ctor); err != nil {
+// MustOverride is a variant of the Override that panics on error.
+func (c *Container) MustOverride(ctor Constructor) {
+	if err := c.Override(ctor); err != nil {
+		panic(err)
+	}
+}
+
+// Clone returns a new container that has the same constructors, processors and group members as this one.
+//
+// Registrations made in the clone don't affect this container and vice versa.
+func (c *Container) Clone() *Container {
+	clone := NewContainer()
+	if c == nil {
+		return clone
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	clone.parallel = c.parallel
+	clone.stopTimeout = c.stopTimeout
+	clone.tracer = c.tracer
+	for t, ctor := range c.constructors {
+		clone.constructors[t] = ctor
+	}
+	for t, pp := range c.processors {
+		processors := make([]Processor, len(pp))
+		copy(processors, pp)
+		clone.processors[t] = processors
+	}
+	for t, mm := range c.groups {
+		members := make([]Member, len(mm))
+		copy(members, mm)
+		clone.groups[t] = members
+	}
+	return clone
+}
+
+// Contribute registers the given constructor in this container as a member of the group
+// of the type returned by the Group function for the type of objects the constructor creates.
+//
+// Multiple members may be registered for one group. When the group is required as a dependency
+// all its members are created and processed in the order they were registered. Destructors
+// of members are called individually.
+//
+// Members are always created on the arena of the group, thus their constructors cannot specify a scope
+// (see the Scoper).
+func (c *Container) Contribute(ctor Constructor) error {
+	if c == nil {
+		return kerror.New(kerror.ENil, "nil container cannot register group member")
+	}
+	if ctor == nil {
+		return kerror.New(kerror.EInvalid, "container cannot register nil group member")
+	}
+	t := ctor.Type()
+	if t == nil {
+		return kerror.New(kerror.EInvalid, "container cannot register group member of nil type")
+	}
+	if scope := ScopeOf(ctor); scope != "" {
+		return kerror.Newf(kerror.EInvalid, "container cannot register group member of %q scope%s",
+			scope, describe(ctor))
+	}
+	gt := Group(t)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if existing, ok := c.constructors[gt]; ok {
+		return kerror.Newf(kerror.EAmbiguous, "%s constructor already registered%s%s",
+			gt, describe(existing), conflicting(ctor))
+	}
+	c.groups[gt] = append(c.groups[gt], Member{Constructor: ctor})
+	return nil
+}
+
+// MustContribute is a variant of the Contribute that panics on error.
+func (c *Container) MustContribute(ctor Constructor) {
+	if err := c.Contribute(ctor); err != nil {
+		panic(err)
+	}
+}
+
+// DeclareGroup declares the group (plain or keyed) of the given type in this container,
+// thus it is resolved to the empty group if no members are registered.
+//
+// The group type must be returned by the Group or the KeyedGroup function. Declaring a group
+// that already has members or was declared before does nothing.
+func (c *Container) DeclareGroup(gt reflect.Type) error {
+	if c == nil {
+		return kerror.New(kerror.ENil, "nil container cannot declare group")
+	}
+	if gt == nil {
+		return kerror.New(kerror.EInvalid, "container cannot declare group of nil type")
+	}
+	at := Actual(gt)
+	if at.Kind() != reflect.Slice && (at.Kind() != reflect.Map || at.Key() != reflect.TypeOf("")) {
+		return kerror.Newf(kerror.EInvalid, "container cannot declare group of %s type", gt)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if existing, ok := c.constructors[gt]; ok {
+		return kerror.Newf(kerror.EAmbiguous, "%s constructor already registered%s", gt, describe(existing))
+	}
+	if _, ok := c.groups[gt]; !ok {
+		c.groups[gt] = []Member{}
+	}
+	return nil
+}
+
+// MustDeclareGroup is a variant of the DeclareGroup that panics on error.
+func (c *Container) MustDeclareGroup(gt reflect.Type) {
+	if err := c.DeclareGroup(gt); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideInto registers the given constructor in this container as a member of the keyed group
+// of the type returned by the KeyedGroup function for the type of objects the constructor creates.
+//
+// Only one member with a key may be registered for one keyed group. When the keyed group is required
+// as a dependency all its members are created and processed in the order they were registered.
+// Destructors of members are called individually.
+func (c *Container) ProvideInto(key string, ctor Constructor) error {
+	if c == nil {
+		return kerror.New(kerror.ENil, "nil container cannot register group member")
+	}
+	if key == "" {
+		return kerror.New(kerror.EInvalid, "container cannot register group member with empty key")
+	}
+	if ctor == nil {
+		return kerror.New(kerror.EInvalid, "container cannot register nil group member")
+	}
+	t := ctor.Type()
+	if t == nil {
+		return kerror.New(kerror.EInvalid, "container cannot register group member of nil type")
+	}
+	if scope := ScopeOf(ctor); scope != "" {
+		return kerror.Newf(kerror.EInvalid, "container cannot register group member of %q scope%s",
+			scope, describe(ctor))
+	}
+	gt := KeyedGroup(t)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if existing, ok := c.constructors[gt]; ok {
+		return kerror.Newf(kerror.EAmbiguous, "%s constructor already registered%s%s",
+			gt, describe(existing), conflicting(ctor))
+	}
+	for _, member := range c.groups[gt] {
+		if member.Key == key {
+			return kerror.Newf(kerror.EAmbiguous, "%s group member with key %q already registered%s%s",
+				gt, key, describe(member.Constructor), conflicting(ctor))
+		}
+	}
+	c.groups[gt] = append(c.groups[gt], Member{Key: key, Constructor: ctor})
+	return nil
+}
+
+// MustProvideInto is a variant of the ProvideInto that panics on error.
+func (c *Container) MustProvideInto(key string, ctor Constructor) {
+	if err := c.ProvideInto(key, ctor); err != nil {
 		panic(err)
 	}
 }
 
 // Attach registers the given processor in this container.
 //
-// Multiple processors may be registered for one type, but there are no guaranty of order of their call.
+// Multiple processors may be registered for one type. They are called in the order they were registered
+// unless some of them implement the Sequencer interface to constrain the order (see the Sequence).
 func (c *Container) Attach(proc Processor) error {
 	if c == nil {
 		return kerror.New(kerror.ENil, "nil container cannot register processor")
@@ -67,6 +302,8 @@ func (c *Container) Attach(proc Processor) error {
 	if t == nil {
 		return kerror.New(kerror.EInvalid, "container cannot register processor for nil type")
 	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	c.processors[t] = append(c.processors[t], proc)
 	return nil
 }
@@ -85,6 +322,8 @@ func (c *Container) Lookup(t reflect.Type) (Constructor, []Processor) {
 	if c == nil || t == nil {
 		return nil, nil
 	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
 	ctor := c.constructors[t]
 	var processors []Processor
 	if pp, ok := c.processors[t]; ok {
@@ -94,46 +333,227 @@ func (c *Container) Lookup(t reflect.Type) (Constructor, []Processor) {
 	return ctor, processors
 }
 
+// Members returns members that are registered for the group (plain or keyed) of the given type in this container.
+//
+// Members are returned in the order they were registered.
+func (c *Container) Members(t reflect.Type) []Member {
+	if c == nil || t == nil {
+		return nil
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	var members []Member
+	if mm, ok := c.groups[t]; ok {
+		members = make([]Member, len(mm))
+		copy(members, mm)
+	}
+	return members
+}
+
+// HasGroup returns boolean specifies whether the group of the given type has members
+// or was declared in this container (see the DeclareGroup).
+func (c *Container) HasGroup(t reflect.Type) bool {
+	if c == nil || t == nil {
+		return false
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	_, ok := c.groups[t]
+	return ok
+}
+
 // Explore calls f for each type presented in this container.
 //
 // Nil constructor indicates that there are no registered constructor for the type
-// but registered processors are there.
+// but registered processors are there. Group types are not presented here, use the
+// ExploreGroups to traverse them.
 //
 // The traversal will be broken if f will return false.
 func (c *Container) Explore(f func(reflect.Type, Constructor, []Processor) (next bool)) {
 	if c == nil || f == nil {
 		return
 	}
+	type entry struct {
+		t          reflect.Type
+		ctor       Constructor
+		processors []Processor
+	}
+	var entries []entry
+	c.mutex.RLock()
 	for t, ctor := range c.constructors {
 		var processors []Processor
 		if pp, ok := c.processors[t]; ok {
 			processors = make([]Processor, len(pp))
 			copy(processors, pp)
 		}
-		if !f(t, ctor, processors) {
+		entries = append(entries, entry{t, ctor, processors})
+	}
+	for t, pp := range c.processors {
+		if _, ok := c.constructors[t]; ok {
+			continue
+		}
+		if _, ok := c.groups[t]; ok {
+			continue
+		}
+		processors := make([]Processor, len(pp))
+		copy(processors, pp)
+		entries = append(entries, entry{t, nil, processors})
+	}
+	c.mutex.RUnlock()
+	for _, e := range entries {
+		if !f(e.t, e.ctor, e.processors) {
 			return
 		}
 	}
-	for t, pp := range c.processors {
-		if _, ok := c.constructors[t]; !ok {
-			processors := make([]Processor, len(pp))
+}
+
+// ExploreGroups calls f for each group type (plain or keyed) presented in this container.
+//
+// The traversal will be broken if f will return false.
+func (c *Container) ExploreGroups(f func(reflect.Type, []Member, []Processor) (next bool)) {
+	if c == nil || f == nil {
+		return
+	}
+	type entry struct {
+		t          reflect.Type
+		members    []Member
+		processors []Processor
+	}
+	var entries []entry
+	c.mutex.RLock()
+	for t, mm := range c.groups {
+		members := make([]Member, len(mm))
+		copy(members, mm)
+		var processors []Processor
+		if pp, ok := c.processors[t]; ok {
+			processors = make([]Processor, len(pp))
 			copy(processors, pp)
-			if !f(t, nil, processors) {
-				return
-			}
 		}
+		entries = append(entries, entry{t, members, processors})
+	}
+	c.mutex.RUnlock()
+	for _, e := range entries {
+		if !f(e.t, e.members, e.processors) {
+			return
+		}
+	}
+}
+
+// SetParallel specifies whether to resolve dependencies concurrently.
+//
+// In the parallel mode parameters of a constructor, processor or functor are resolved
+// concurrently, thus independent subgraphs of dependencies are built in parallel.
+// Each object is still created exactly once per arena and objects are still destroyed
+// in the reverse order of their creation, so that any object is destroyed before
+// objects it depends on. Constructors and processors must be safe for concurrent use
+// in this mode.
+//
+// The dependency graph of each functor is checked for cycles before the parallel resolution.
+func (c *Container) SetParallel(parallel bool) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.parallel = parallel
+}
+
+// Parallel returns boolean specifies whether this container resolves dependencies concurrently.
+func (c *Container) Parallel() bool {
+	if c == nil {
+		return false
 	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.parallel
+}
+
+// SetStopTimeout specifies the time limit for stopping each object that implements the Stopper interface.
+//
+// When the limit is exceeded the stop is considered as failed and the arena finalization continues
+// without waiting for the object. The zero timeout (which is default) means no time limit.
+func (c *Container) SetStopTimeout(timeout time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stopTimeout = timeout
+}
+
+// StopTimeout returns the time limit for stopping each object that implements the Stopper interface.
+func (c *Container) StopTimeout() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.stopTimeout
+}
+
+// SetTracer specifies the tracer this container will report to while running functors.
+//
+// The nil tracer (which is default) disables tracing.
+func (c *Container) SetTracer(tracer Tracer) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tracer = tracer
+}
+
+// Tracer returns the tracer this container reports to.
+func (c *Container) Tracer() Tracer {
+	if c == nil {
+		return nil
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.tracer
+}
+
+// newArena returns a new arena of the given scope with given parent arenas configured by this container.
+func (c *Container) newArena(scope Scope, parents ...*Arena) *Arena {
+	arena := NewArena(parents...)
+	arena.scope = scope
+	arena.stopTimeout = c.StopTimeout()
+	return arena
 }
 
 // Run runs given functors sequentially resolving their dependencies recursively using this container.
 // If some functor returns further functors all of them will be run before the running of functors that follows it.
 //
-// All objects created during run will be automatically destroyed when it ends.
-func (c *Container) Run(functors ...Functor) (err error) {
+// Several runs may be performed simultaneously, each of them uses its own arena.
+//
+// Objects implementing the Starter interface will be started right after their creation.
+// All objects created during run will be automatically stopped (see the Stopper interface)
+// and destroyed when it ends.
+func (c *Container) Run(functors ...Functor) error {
+	return c.RunContext(context.Background(), functors...)
+}
+
+// MustRun is a variant of the Run that panics on error.
+func (c *Container) MustRun(functors ...Functor) {
+	if err := c.Run(functors...); err != nil {
+		panic(err)
+	}
+}
+
+// RunContext runs given functors like the Run does but with the given context.
+//
+// The context is registered on the arena as an object of the context.Context type,
+// thus constructors, processors and functors may depend on it, e.g. to abort a hung dial.
+// The context is checked between resolution steps: when it is done the run will be interrupted
+// and all objects created so far will be destroyed.
+func (c *Container) RunContext(ctx context.Context, functors ...Functor) (err error) {
 	if c == nil {
 		return kerror.New(kerror.ENil, "nil container cannot run functors")
 	}
-	arena := NewArena()
+	if ctx == nil {
+		return kerror.New(kerror.EInvalid, "container cannot run functors with nil context")
+	}
+	arena := c.newArena(Singleton)
 	defer func() {
 		err = kerror.Join(err, arena.Finalize())
 	}()
@@ -144,51 +564,151 @@ func (c *Container) Run(functors ...Functor) (err error) {
 	if err := arena.Put(reflect.TypeOf(runtime), reflect.ValueOf(runtime), kdone.Noop); err != nil {
 		return err
 	}
-	return c.run(arena, functors)
+	if err := putContext(arena, ctx); err != nil {
+		return err
+	}
+	return c.run(ctx, arena, nil, functors)
 }
 
-// MustRun is a variant of the Run that panics on error.
-func (c *Container) MustRun(functors ...Functor) {
-	if err := c.Run(functors...); err != nil {
+// MustRunContext is a variant of the RunContext that panics on error.
+func (c *Container) MustRunContext(ctx context.Context, functors ...Functor) {
+	if err := c.RunContext(ctx, functors...); err != nil {
 		panic(err)
 	}
 }
 
-// run runs given functors using the given arena.
-func (c *Container) run(arena *Arena, functors []Functor) error {
+// run runs given functors using the given context and arena.
+//
+// The parent resolution is specified when functors are run by a constructor or a processor
+// (nil otherwise), thus dependency cycles closed through the run are detected.
+func (c *Container) run(ctx context.Context, arena *Arena, parent *resolution, functors []Functor) error {
 	for _, fun := range functors {
 		if fun == nil {
 			return kerror.New(kerror.EInvalid, "container cannot run nil functor")
 		}
-		a, err := c.resolveTypes(arena, fun.Parameters())
+		res := newResolution(ctx, arena, c.Parallel(), c.Tracer())
+		res.parent = parent
+		if res.parallel {
+			if err := c.checkCycles(res, fun.Parameters()); err != nil {
+				return err
+			}
+		}
+		a, err := c.resolveTypes(res, fun, fun.Parameters())
 		if err != nil {
 			return err
 		}
+		if err := interrupted(ctx); err != nil {
+			return err
+		}
+		called := time.Now()
 		further, err := fun.Call(a...)
+		if res.tracer != nil {
+			res.tracer.OnFunctorCall(fun, time.Since(called), err)
+		}
 		if err != nil {
 			return err
 		}
-		if err := c.run(arena, further); err != nil {
+		if err := c.run(ctx, arena, parent, further); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// resolveType returns the object of the given type. If the object is already on the given arena, it will be used.
+// resolve returns the object of the given type using the given context and arena.
+//
+// The parent resolution is specified when the object is resolved by a constructor or a processor
+// (nil otherwise), thus dependency cycles closed through the runtime are detected.
+func (c *Container) resolve(ctx context.Context, arena *Arena, parent *resolution, t reflect.Type) (reflect.Value, error) {
+	res := newResolution(ctx, arena, c.Parallel(), c.Tracer())
+	res.parent = parent
+	if res.parallel {
+		if err := c.checkCycles(res, []reflect.Type{t}); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return c.resolveType(res, t)
+}
+
+// resolveType returns the object of the given type. If the object is already on the arena, it will be used.
 // Otherwise it will be firstly created and processed using this container and registered on the arena.
-func (c *Container) resolveType(arena *Arena, t reflect.Type) (reflect.Value, error) {
+func (c *Container) resolveType(res *resolution, t reflect.Type) (reflect.Value, error) {
 	if t == nil {
 		return reflect.Value{}, kerror.New(kerror.EInvalid, "container cannot resolve dependency of nil type")
 	}
-	if obj, ok := arena.Get(t); ok {
+	if obj, ok := res.arena.Get(t); ok {
+		if t == runtimeType && res.parent != nil {
+			runtime := obj.Interface().(*Runtime)
+			// Constructors and processors get the runtime bound to their resolution branch,
+			// thus resolving objects they are required by fails instead of waiting forever.
+			return reflect.ValueOf(runtime.within(res)), nil
+		}
 		return obj, nil
 	}
-	ctor, ok := c.constructors[t]
-	if !ok {
-		return reflect.Value{}, kerror.Newf(kerror.ENotFound, "%s constructor is not registered", t)
+	if c.isLazy(t) {
+		return c.resolveLazy(res, t), nil
+	}
+	if c.isOptional(t) {
+		return c.resolveOptional(res, t)
+	}
+	if res.resolving(t) {
+		return reflect.Value{}, c.cycleError(res, t)
+	}
+	if ctor, _ := c.Lookup(t); ScopeOf(ctor) == Transient {
+		// Transient objects are never shared, thus they are created bypassing the arena.
+		return c.createType(res.branch(t, res.arena), t)
+	}
+	arena, err := c.scopeArena(res, t)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return arena.obtain(t, func() (reflect.Value, error) {
+		return c.createType(res.branch(t, arena), t)
+	})
+}
+
+// scopeArena returns the arena objects of the given type must be registered on according to the scope
+// of their constructor. Dependencies of these objects are resolved using the same arena, thus objects
+// of the Singleton scope never depend on objects of shorter scopes.
+func (c *Container) scopeArena(res *resolution, t reflect.Type) (*Arena, error) {
+	ctor, _ := c.Lookup(t)
+	scope := ScopeOf(ctor)
+	if scope == "" {
+		return res.arena, nil
+	}
+	arena := res.arena.scoped(scope)
+	if arena == nil {
+		return nil, kerror.Newf(kerror.EIllegal, "%s object cannot be created outside of %q scope%s",
+			t, scope, describe(ctor))
+	}
+	return arena, nil
+}
+
+// createType creates, processes and registers on the arena the object of the given type.
+//
+// Objects of the Transient scope are not registered, the arena only takes the responsibility
+// for calling their destructors.
+func (c *Container) createType(res *resolution, t reflect.Type) (obj reflect.Value, err error) {
+	defer res.end()
+	var ctor Constructor
+	if res.tracer != nil {
+		res.tracer.OnResolveStart(t)
+		defer func(start time.Time) {
+			res.tracer.OnResolveEnd(t, ctor, time.Since(start), err)
+		}(time.Now())
+	}
+	if err := interrupted(res.ctx); err != nil {
+		return reflect.Value{}, err
+	}
+	if c.HasGroup(t) {
+		return c.createGroup(res, t, c.Members(t))
+	}
+	ctor, _ = c.Lookup(t)
+	if ctor == nil {
+		return reflect.Value{}, kerror.Newf(kerror.ENotFound, "%s constructor is not registered%s",
+			t, res.parent.requirer())
 	}
-	a, err := c.resolveTypes(arena, ctor.Parameters())
+	a, err := c.resolveTypes(res, ctor, ctor.Parameters())
 	if err != nil {
 		return reflect.Value{}, err
 	}
@@ -196,30 +716,218 @@ func (c *Container) resolveType(arena *Arena, t reflect.Type) (reflect.Value, er
 	if err != nil {
 		return reflect.Value{}, err
 	}
-	for _, proc := range c.processors[t] {
-		a, err := c.resolveTypes(arena, proc.Parameters())
+	dtor = traceDestructor(res.tracer, t, dtor)
+	if err := c.process(res, t, obj); err != nil {
+		return reflect.Value{}, discard(err, dtor)
+	}
+	// The object is registered only after it was started, thus a failed start
+	// doesn't leave it on the arena and the next resolution creates it again.
+	if err := start(contextOf(res.arena), res.arena, obj); err != nil {
+		return reflect.Value{}, discard(err, dtor)
+	}
+	if ScopeOf(ctor) == Transient {
+		err = res.arena.assume(dtor)
+	} else {
+		err = res.arena.Put(t, obj, dtor)
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return obj, nil
+}
+
+// createGroup creates, processes and registers on the arena the group (plain or keyed) of the given type
+// consisting of objects created by given members. Objects are registered together as a group while
+// their destructors are registered individually.
+func (c *Container) createGroup(res *resolution, t reflect.Type, members []Member) (reflect.Value, error) {
+	gt := Actual(t)
+	var group reflect.Value
+	if gt.Kind() == reflect.Map {
+		group = reflect.MakeMapWithSize(gt, len(members))
+	} else {
+		group = reflect.MakeSlice(gt, 0, len(members))
+	}
+	for _, member := range members {
+		if err := interrupted(res.ctx); err != nil {
+			return reflect.Value{}, err
+		}
+		ctor := member.Constructor
+		a, err := c.resolveTypes(res, ctor, ctor.Parameters())
 		if err != nil {
 			return reflect.Value{}, err
 		}
-		if err := proc.Process(obj, a...); err != nil {
+		obj, dtor, err := ctor.Create(a...)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		dtor = traceDestructor(res.tracer, ctor.Type(), dtor)
+		if !obj.IsValid() || !obj.Type().AssignableTo(gt.Elem()) {
+			err := kerror.Newf(kerror.EInvalid, "%s group member%s created invalid object", t, describe(ctor))
+			return reflect.Value{}, discard(err, dtor)
+		}
+		if err := c.process(res, ctor.Type(), obj); err != nil {
+			return reflect.Value{}, discard(err, dtor)
+		}
+		if err := start(contextOf(res.arena), res.arena, obj); err != nil {
+			return reflect.Value{}, discard(err, dtor)
+		}
+		if err := res.arena.assume(dtor); err != nil {
 			return reflect.Value{}, err
 		}
+		if gt.Kind() == reflect.Map {
+			group.SetMapIndex(reflect.ValueOf(member.Key), obj)
+		} else {
+			group = reflect.Append(group, obj)
+		}
 	}
-	if err := arena.Put(t, obj, dtor); err != nil {
+	if err := c.process(res, t, group); err != nil {
 		return reflect.Value{}, err
 	}
-	return obj, nil
+	if err := res.arena.Put(t, group, kdone.Noop); err != nil {
+		return reflect.Value{}, err
+	}
+	return group, nil
+}
+
+// process processes the given object of the given type by processors registered in this container.
+func (c *Container) process(res *resolution, t reflect.Type, obj reflect.Value) error {
+	_, processors := c.Lookup(t)
+	processors, err := Sequence(processors)
+	if err != nil {
+		return err
+	}
+	for _, proc := range processors {
+		if err := interrupted(res.ctx); err != nil {
+			return err
+		}
+		if err := c.processObject(res, t, obj, proc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processObject processes the given object of the given type by the given processor.
+func (c *Container) processObject(res *resolution, t reflect.Type, obj reflect.Value, proc Processor) (err error) {
+	if res.tracer != nil {
+		defer func(start time.Time) {
+			res.tracer.OnProcess(t, proc, time.Since(start), err)
+		}(time.Now())
+	}
+	a, err := c.resolveTypes(res, proc, proc.Parameters())
+	if err != nil {
+		return err
+	}
+	return proc.Process(obj, a...)
 }
 
 // resolveTypes resolves given types together.
-func (c *Container) resolveTypes(arena *Arena, types []reflect.Type) ([]reflect.Value, error) {
+//
+// In the parallel mode types are resolved concurrently.
+func (c *Container) resolveTypes(res *resolution, owner interface{}, types []reflect.Type) ([]reflect.Value, error) {
+	res = res.by(owner)
 	objects := make([]reflect.Value, len(types))
-	for i, t := range types {
-		obj, err := c.resolveType(arena, t)
-		if err != nil {
-			return nil, err
+	if !res.parallel || len(types) < 2 {
+		for i, t := range types {
+			obj, err := c.resolveType(res, t)
+			if err != nil {
+				return nil, err
+			}
+			objects[i] = obj
 		}
-		objects[i] = obj
+		return objects, nil
+	}
+	errs := make([]error, len(types))
+	var wg sync.WaitGroup
+	wg.Add(len(types))
+	for i, t := range types {
+		go func(i int, t reflect.Type) {
+			defer wg.Done()
+			errs[i] = kerror.Try(func() (err error) {
+				objects[i], err = c.resolveType(res, t)
+				return err
+			})
+		}(i, t)
+	}
+	wg.Wait()
+	if err := kerror.Join(errs...); err != nil {
+		return nil, err
 	}
 	return objects, nil
 }
+
+// cycleError returns the error indicating the dependency cycle closed by the given type
+// along with descriptions of constructors of types forming the cycle.
+func (c *Container) cycleError(res *resolution, t reflect.Type) error {
+	var descriptions []string
+	for _, ct := range res.cycleTypes(t) {
+		ctor, _ := c.Lookup(ct)
+		if s := Describe(ctor).String(); s != "" {
+			descriptions = append(descriptions, ct.String()+": "+s)
+		}
+	}
+	var s string
+	if len(descriptions) > 0 {
+		s = " (" + strings.Join(descriptions, "; ") + ")"
+	}
+	return kerror.Newf(kerror.EAmbiguous, "cyclic dependency: %s%s", res.cycle(t), s)
+}
+
+// checkCycles checks that the dependency graph of given types has no cycles.
+//
+// This check is required before the parallel resolution since concurrent creations
+// of cyclically dependent objects would wait for each other forever.
+func (c *Container) checkCycles(res *resolution, types []reflect.Type) error {
+	history := make(map[reflect.Type]bool)
+	var check func(res *resolution, t reflect.Type) error
+	check = func(res *resolution, t reflect.Type) error {
+		if t == nil {
+			return nil
+		}
+		if res.resolving(t) {
+			return c.cycleError(res, t)
+		}
+		if history[t] {
+			return nil
+		}
+		history[t] = true
+		if _, ok := res.arena.Get(t); ok {
+			return nil
+		}
+		if c.isLazy(t) {
+			// Lazy dependencies break cycles since they are resolved only on call.
+			return nil
+		}
+		if c.isOptional(t) {
+			return check(res, OptionalTarget(t))
+		}
+		var dependencies []reflect.Type
+		ctor, processors := c.Lookup(t)
+		if ctor != nil {
+			dependencies = append(dependencies, ctor.Parameters()...)
+		}
+		for _, member := range c.Members(t) {
+			dependencies = append(dependencies, member.Constructor.Parameters()...)
+			_, pp := c.Lookup(member.Constructor.Type())
+			for _, proc := range pp {
+				dependencies = append(dependencies, proc.Parameters()...)
+			}
+		}
+		for _, proc := range processors {
+			dependencies = append(dependencies, proc.Parameters()...)
+		}
+		branch := res.branch(t, res.arena)
+		for _, d := range dependencies {
+			if err := check(branch, d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, t := range types {
+		if err := check(res, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
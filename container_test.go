@@ -1,8 +1,12 @@
 package kinit
 
 import (
+	"context"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
@@ -105,6 +109,35 @@ func TestContainer_Provide__AmbiguousConstructor(t *testing.T) {
 	}
 }
 
+func TestContainer_ProvideAll__AmbiguousConstructor(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(newTestObject2))
+	err := ctr.ProvideAll(newTestConstructor(newTestObject1), newTestConstructor(newTestObject2))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+	if ctor, _ := ctr.Lookup(reflect.TypeOf((*testObject1)(nil))); ctor != nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_ProvideAll__DuplicateConstructor(t *testing.T) {
+	ctr := NewContainer()
+	err := ctr.ProvideAll(newTestConstructor(newTestObject1), newTestConstructor(newTestObject1))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+	if ctor, _ := ctr.Lookup(reflect.TypeOf((*testObject1)(nil))); ctor != nil {
+		t.Fail()
+		return
+	}
+}
+
 func TestContainer_Attach__NilProcessor(t *testing.T) {
 	ctr := NewContainer()
 	err := ctr.Attach(nil)
@@ -469,3 +502,370 @@ func TestNilContainer_Run(t *testing.T) {
 		return
 	}
 }
+
+func TestContainer_Override(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (int, kdone.Destructor, error) { return 1, kdone.Noop, nil }))
+	ctor := newTestConstructor(func() (int, kdone.Destructor, error) { return 2, kdone.Noop, nil })
+	ctr.MustOverride(ctor)
+	if c, _ := ctr.Lookup(reflect.TypeOf(0)); c != ctor {
+		t.Fail()
+		return
+	}
+	ctr.MustRun(newTestFunctor(func(i int) ([]Functor, error) {
+		if i != 2 {
+			return nil, kerror.Newf(kerror.EInvalid, "2 expected, %d given", i)
+		}
+		return nil, nil
+	}))
+}
+
+func TestContainer_Override__Group(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustContribute(newTestConstructor(func() (int, kdone.Destructor, error) { return 1, kdone.Noop, nil }))
+	ctor := newTestConstructor(func() ([]int, kdone.Destructor, error) { return []int{2}, kdone.Noop, nil })
+	ctr.MustOverride(ctor)
+	if c, _ := ctr.Lookup(reflect.TypeOf([]int{})); c != ctor {
+		t.Fail()
+		return
+	}
+	if members := ctr.Members(reflect.TypeOf([]int{})); len(members) > 0 {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Override__NilConstructor(t *testing.T) {
+	err := NewContainer().Override(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Override__ConstructorWithBrokenType(t *testing.T) {
+	err := NewContainer().Override(testConstructorWithBrokenType{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Clone(t *testing.T) {
+	ctor1 := newTestConstructor(func() (int, kdone.Destructor, error) { return 1, kdone.Noop, nil })
+	ctor2 := newTestConstructor(func() (int, kdone.Destructor, error) { return 2, kdone.Noop, nil })
+	proc1 := newTestProcessor(func(int) error { return nil })
+	proc2 := newTestProcessor(func(int) error { return nil })
+	member1 := newTestConstructor(func() (int16, kdone.Destructor, error) { return 1, kdone.Noop, nil })
+	member2 := newTestConstructor(func() (int16, kdone.Destructor, error) { return 2, kdone.Noop, nil })
+	ctr := NewContainer()
+	ctr.MustProvide(ctor1)
+	ctr.MustAttach(proc1)
+	ctr.MustContribute(member1)
+	clone := ctr.Clone()
+	clone.MustOverride(ctor2)
+	clone.MustAttach(proc2)
+	clone.MustContribute(member2)
+	if c, pp := ctr.Lookup(reflect.TypeOf(0)); c != ctor1 || len(pp) != 1 || pp[0] != proc1 {
+		t.Fail()
+		return
+	}
+	if mm := ctr.Members(reflect.TypeOf([]int16{})); len(mm) != 1 || mm[0].Constructor != member1 {
+		t.Fail()
+		return
+	}
+	if c, pp := clone.Lookup(reflect.TypeOf(0)); c != ctor2 || len(pp) != 2 || pp[0] != proc1 || pp[1] != proc2 {
+		t.Fail()
+		return
+	}
+	if mm := clone.Members(reflect.TypeOf([]int16{})); len(mm) != 2 {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_Override(t *testing.T) {
+	err := (*Container)(nil).Override(newTestConstructor(newTestObject1))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_Clone(t *testing.T) {
+	if (*Container)(nil).Clone() == nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__CyclicDependency(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func(int64) (int16, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(int16) (int32, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(int32) (int64, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(int64) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_SetParallel(t *testing.T) {
+	(*Container)(nil).SetParallel(true)
+	if (*Container)(nil).Parallel() {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__Parallel(t *testing.T) {
+	var created int32
+	var mutex sync.Mutex
+	var destroyed []string
+	destructor := func(name string) kdone.Destructor {
+		return kdone.DestructorFunc(func() error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			destroyed = append(destroyed, name)
+			return nil
+		})
+	}
+	// The barrier guarantees that all three connections are opened simultaneously.
+	var barrier sync.WaitGroup
+	barrier.Add(3)
+	connect := func(name string) (kdone.Destructor, error) {
+		barrier.Done()
+		done := make(chan struct{})
+		go func() {
+			barrier.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return destructor(name), nil
+		case <-time.After(5 * time.Second):
+			return nil, kerror.Newf(kerror.ERuntime, "%s was not connected concurrently", name)
+		}
+	}
+	ctr := NewContainer()
+	ctr.SetParallel(true)
+	if !ctr.Parallel() {
+		t.Fail()
+		return
+	}
+	ctr.MustProvide(newTestConstructor(func() (*uint8, kdone.Destructor, error) {
+		atomic.AddInt32(&created, 1)
+		return new(uint8), destructor("config"), nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(*uint8) (*int16, kdone.Destructor, error) {
+		dtor, err := connect("database")
+		return new(int16), dtor, err
+	}))
+	ctr.MustProvide(newTestConstructor(func(*uint8) (*int32, kdone.Destructor, error) {
+		dtor, err := connect("kafka")
+		return new(int32), dtor, err
+	}))
+	ctr.MustProvide(newTestConstructor(func(*uint8) (*int64, kdone.Destructor, error) {
+		dtor, err := connect("redis")
+		return new(int64), dtor, err
+	}))
+	ctr.MustRun(newTestFunctor(func(*int16, *int32, *int64) ([]Functor, error) {
+		return nil, nil
+	}))
+	if created != 1 {
+		t.Logf("config was created %d times", created)
+		t.Fail()
+		return
+	}
+	if len(destroyed) != 4 || destroyed[3] != "config" {
+		t.Logf("%v", destroyed)
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__ParallelErrorProneConstructor(t *testing.T) {
+	ctr := NewContainer()
+	ctr.SetParallel(true)
+	ctr.MustProvide(newTestConstructor(func() (int32, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func() (int64, kdone.Destructor, error) {
+		return 0, kdone.Noop, kerror.New(kerror.Label("test.Error"), "test error")
+	}))
+	err := ctr.Run(newTestFunctor(func(int32, int64) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.Label("test.Error") {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__ParallelCyclicDependency(t *testing.T) {
+	ctr := NewContainer()
+	ctr.SetParallel(true)
+	ctr.MustProvide(newTestConstructor(func(int64) (int32, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(int32) (int64, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(int32, int64) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__Concurrent(t *testing.T) {
+	var created int32
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (*int32, kdone.Destructor, error) {
+		atomic.AddInt32(&created, 1)
+		return new(int32), kdone.Noop, nil
+	}))
+	ctr.MustAttach(newTestProcessor(func(x *int32) error {
+		*x++
+		return nil
+	}))
+	errs := make([]error, 8)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = ctr.Run(newTestFunctor(func(x *int32) ([]Functor, error) {
+				if *x != 1 {
+					return nil, kerror.Newf(kerror.EInvalid, "1 expected, %d given", *x)
+				}
+				return nil, nil
+			}))
+		}(i)
+	}
+	// Registrations are allowed during runs.
+	ctr.MustProvide(newTestConstructor(func() (*int64, kdone.Destructor, error) {
+		return new(int64), kdone.Noop, nil
+	}))
+	ctr.Explore(func(reflect.Type, Constructor, []Processor) bool {
+		return true
+	})
+	wg.Wait()
+	if err := kerror.Join(errs...); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if created != int32(len(errs)) {
+		t.Logf("%d objects expected, %d created", len(errs), created)
+		t.Fail()
+		return
+	}
+}
+
+type testContextKey struct{}
+
+func TestContainer_RunContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testContextKey{}, "test")
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func(ctx context.Context) (string, kdone.Destructor, error) {
+		return ctx.Value(testContextKey{}).(string), kdone.Noop, nil
+	}))
+	ctr.MustRunContext(ctx, newTestFunctor(func(s string) ([]Functor, error) {
+		if s != "test" {
+			return nil, kerror.Newf(kerror.EInvalid, "%q expected, %q given", "test", s)
+		}
+		return nil, nil
+	}))
+}
+
+func TestContainer_Run__BackgroundContext(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustRun(newTestFunctor(func(ctx context.Context) ([]Functor, error) {
+		if ctx != context.Background() {
+			return nil, kerror.New(kerror.EInvalid, "background context expected")
+		}
+		return nil, nil
+	}))
+}
+
+func TestContainer_RunContext__Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var destroyed, created bool
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (int32, kdone.Destructor, error) {
+		cancel()
+		return 0, kdone.DestructorFunc(func() error {
+			destroyed = true
+			return nil
+		}), nil
+	}))
+	ctr.MustProvide(newTestConstructor(func() (int64, kdone.Destructor, error) {
+		created = true
+		return 0, kdone.Noop, nil
+	}))
+	err := ctr.RunContext(ctx, newTestFunctor(func(int32, int64) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ERuntime {
+		t.Fail()
+		return
+	}
+	if created || !destroyed {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_RunContext__CanceledBeforeFunctor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var called bool
+	ctr := NewContainer()
+	err := ctr.RunContext(ctx, newTestFunctor(func() ([]Functor, error) {
+		called = true
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ERuntime || called {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_RunContext__NilContext(t *testing.T) {
+	err := NewContainer().RunContext(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_RunContext(t *testing.T) {
+	err := (*Container)(nil).RunContext(context.Background())
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
@@ -0,0 +1,37 @@
+package kinit
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+// contextType specifies the reflection to the context.Context interface.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// putContext registers the given context on the given arena as an object of the context.Context type.
+func putContext(arena *Arena, ctx context.Context) error {
+	return arena.Put(contextType, reflect.ValueOf(&ctx).Elem(), kdone.Noop)
+}
+
+// contextOf returns the context registered on the given arena.
+//
+// The background context will be returned if there is no registered context.
+func contextOf(arena *Arena) context.Context {
+	if obj, ok := arena.Get(contextType); ok {
+		if ctx, ok := obj.Interface().(context.Context); ok {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// interrupted returns an error if the given context is already done.
+func interrupted(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return kerror.Wrap(err, kerror.ERuntime, "run was interrupted")
+	}
+	return nil
+}
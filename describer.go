@@ -0,0 +1,67 @@
+package kinit
+
+// Describer represents an optional interface of a constructor, processor or functor
+// that is able to point to the source code it is based on.
+//
+// Descriptions are used in error messages of the container and the inspector.
+type Describer interface {
+	// Describe returns the description of this entity.
+	Describe() Description
+}
+
+// Description represents a description of a constructor, processor or functor.
+type Description struct {
+	// Name specifies the name of a function (or type) the described entity is based on.
+	Name string
+	// Location specifies the source location of a function the described entity is based on
+	// in the file:line format. The empty string means an unknown location.
+	Location string
+	// Caller specifies the source location the described entity was created at
+	// in the file:line format. The empty string means an unknown location.
+	Caller string
+}
+
+// String implements the fmt.Stringer interface.
+func (d Description) String() string {
+	s := d.Name
+	if d.Location != "" {
+		if s != "" {
+			s += " "
+		}
+		s += "at " + d.Location
+	}
+	if d.Caller != "" && d.Caller != d.Location {
+		if s != "" {
+			s += " "
+		}
+		s += "registered at " + d.Caller
+	}
+	return s
+}
+
+// Describe returns the description of the given entity if it implements the Describer interface.
+// Otherwise the zero description will be returned.
+func Describe(x interface{}) Description {
+	if d, ok := x.(Describer); ok {
+		return d.Describe()
+	}
+	return Description{}
+}
+
+// describe returns the string representation of the description of the given entity in parentheses
+// prefixed by a space or the empty string if the entity cannot be described.
+func describe(x interface{}) string {
+	if s := Describe(x).String(); s != "" {
+		return " (" + s + ")"
+	}
+	return ""
+}
+
+// conflicting returns the string representation of the description of the given entity that conflicts
+// with an already registered one prefixed by a semicolon or the empty string if the entity cannot be described.
+func conflicting(x interface{}) string {
+	if s := describe(x); s != "" {
+		return "; conflicting one" + s
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package kinit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+func TestDescription_String(t *testing.T) {
+	d := Description{Name: "main.NewDB", Location: "db.go:12", Caller: "main.go:20"}
+	if s := d.String(); s != "main.NewDB at db.go:12 registered at main.go:20" {
+		t.Log(s)
+		t.Fail()
+		return
+	}
+	d = Description{Name: "*main.Config", Caller: "main.go:21"}
+	if s := d.String(); s != "*main.Config registered at main.go:21" {
+		t.Log(s)
+		t.Fail()
+		return
+	}
+	if s := (Description{}).String(); s != "" {
+		t.Log(s)
+		t.Fail()
+		return
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	if Describe(newTestConstructor(func() int { return 0 })) != (Description{}) {
+		t.Fail()
+		return
+	}
+	if Describe(testDescribedConstructor{}).Name != "test" {
+		t.Fail()
+		return
+	}
+}
+
+type testDescribedConstructor struct {
+	*testConstructor
+}
+
+func (testDescribedConstructor) Describe() Description {
+	return Description{Name: "test"}
+}
+
+func TestContainer_Provide__DescribedConflict(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(testDescribedConstructor{newTestConstructor(func() (int, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	})})
+	err := ctr.Provide(testDescribedConstructor{newTestConstructor(func() (int, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	})})
+	t.Logf("%+v", err)
+	if err == nil || !strings.Contains(err.Error(), "int constructor already registered (test); conflicting one (test)") {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__DescribedNotFound(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(testDescribedConstructor{newTestConstructor(func(int32) (int64, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	})})
+	err := ctr.Run(newTestFunctor(func(int64) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound ||
+		!strings.Contains(err.Error(), "int32 constructor is not registered (required by int64 test)") {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__DescribedCycle(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(testDescribedConstructor{newTestConstructor(func(int32) (int16, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	})})
+	ctr.MustProvide(testDescribedConstructor{newTestConstructor(func(int16) (int32, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	})})
+	err := ctr.Run(newTestFunctor(func(int16) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous ||
+		!strings.Contains(err.Error(), "cyclic dependency: int16 🠖 int32 🠖 int16 (int16: test; int32: test)") {
+		t.Fail()
+		return
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/go-kata/kinit
 
-go 1.14
+go 1.18
 
 require (
 	github.com/go-kata/kdone v0.2.9
@@ -0,0 +1,34 @@
+package kinit
+
+import "reflect"
+
+// Group returns the type of the group which members creating objects of the given type contribute to.
+//
+// The group of objects of the type T has the []T type. If the given type is qualified by a name,
+// the group type will be qualified by the same name.
+func Group(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	return Named(reflect.SliceOf(Actual(t)), NameOf(t))
+}
+
+// KeyedGroup returns the type of the keyed group which members creating objects of the given type contribute to.
+//
+// The keyed group of objects of the type T has the map[string]T type. If the given type is qualified by a name,
+// the keyed group type will be qualified by the same name.
+func KeyedGroup(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	return Named(reflect.MapOf(reflect.TypeOf(""), Actual(t)), NameOf(t))
+}
+
+// Member represents a group member.
+type Member struct {
+	// Key specifies the key of an object in a keyed group.
+	// The empty string means that a member belongs to a plain group.
+	Key string
+	// Constructor specifies the constructor of an object.
+	Constructor Constructor
+}
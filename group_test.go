@@ -0,0 +1,341 @@
+package kinit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+func TestGroup(t *testing.T) {
+	it := reflect.TypeOf(0)
+	if Group(it) != reflect.TypeOf([]int{}) {
+		t.Fail()
+		return
+	}
+	if Group(Named(it, "handlers")) != Named(reflect.TypeOf([]int{}), "handlers") {
+		t.Fail()
+		return
+	}
+	if Group(nil) != nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer__Group(t *testing.T) {
+	var destroyed []int
+	newMember := func(i int) Constructor {
+		return newTestConstructor(func(base int16) (int, kdone.Destructor, error) {
+			return int(base) + i, kdone.DestructorFunc(func() error {
+				destroyed = append(destroyed, i)
+				return nil
+			}), nil
+		})
+	}
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (int16, kdone.Destructor, error) { return 10, kdone.Noop, nil }))
+	ctr.MustContribute(newMember(1))
+	ctr.MustContribute(newMember(2))
+	processed := 0
+	ctr.MustAttach(newTestProcessor(func(int) error {
+		processed++
+		return nil
+	}))
+	ctr.MustAttach(newTestProcessor(func(group []int) error {
+		group[0] *= 10
+		return nil
+	}))
+	ctr.MustRun(newTestFunctor(func(group []int) ([]Functor, error) {
+		if len(group) != 2 || group[0] != 110 || group[1] != 12 {
+			return nil, kerror.Newf(kerror.EInvalid, "[110 12] expected, %v given", group)
+		}
+		if processed != 2 {
+			return nil, kerror.Newf(kerror.EInvalid, "2 processed members expected, %d given", processed)
+		}
+		return nil, nil
+	}))
+	if len(destroyed) != 2 || destroyed[0] != 2 || destroyed[1] != 1 {
+		t.Logf("%v", destroyed)
+		t.Fail()
+		return
+	}
+}
+
+func TestKeyedGroup(t *testing.T) {
+	it := reflect.TypeOf(0)
+	if KeyedGroup(it) != reflect.TypeOf(map[string]int{}) {
+		t.Fail()
+		return
+	}
+	if KeyedGroup(Named(it, "drivers")) != Named(reflect.TypeOf(map[string]int{}), "drivers") {
+		t.Fail()
+		return
+	}
+	if KeyedGroup(nil) != nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer__KeyedGroup(t *testing.T) {
+	var destroyed []int
+	newMember := func(i int) Constructor {
+		return newTestConstructor(func() (int, kdone.Destructor, error) {
+			return i, kdone.DestructorFunc(func() error {
+				destroyed = append(destroyed, i)
+				return nil
+			}), nil
+		})
+	}
+	ctr := NewContainer()
+	ctr.MustProvideInto("one", newMember(1))
+	ctr.MustProvideInto("two", newMember(2))
+	ctr.MustContribute(newMember(3))
+	ctr.MustRun(newTestFunctor(func(registry map[string]int, group []int) ([]Functor, error) {
+		if len(registry) != 2 || registry["one"] != 1 || registry["two"] != 2 {
+			return nil, kerror.Newf(kerror.EInvalid, "map[one:1 two:2] expected, %v given", registry)
+		}
+		if len(group) != 1 || group[0] != 3 {
+			return nil, kerror.Newf(kerror.EInvalid, "[3] expected, %v given", group)
+		}
+		return nil, nil
+	}))
+	if len(destroyed) != 3 || destroyed[0] != 3 || destroyed[1] != 2 || destroyed[2] != 1 {
+		t.Logf("%v", destroyed)
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_ProvideInto__AmbiguousKey(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvideInto("one", newTestConstructor(func() (int, kdone.Destructor, error) { return 1, kdone.Noop, nil }))
+	err := ctr.ProvideInto("one", newTestConstructor(func() (int, kdone.Destructor, error) { return 2, kdone.Noop, nil }))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_ProvideInto__AmbiguousConstructor(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (map[string]int, kdone.Destructor, error) { return nil, kdone.Noop, nil }))
+	err := ctr.ProvideInto("one", newTestConstructor(func() (int, kdone.Destructor, error) { return 1, kdone.Noop, nil }))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_ProvideInto__EmptyKey(t *testing.T) {
+	err := NewContainer().ProvideInto("", newTestConstructor(newTestObject1))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_ProvideInto__NilConstructor(t *testing.T) {
+	err := NewContainer().ProvideInto("one", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Contribute__NilConstructor(t *testing.T) {
+	err := NewContainer().Contribute(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Contribute__ConstructorWithBrokenType(t *testing.T) {
+	err := NewContainer().Contribute(testConstructorWithBrokenType{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Contribute__AmbiguousConstructor(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() ([]int, kdone.Destructor, error) { return nil, kdone.Noop, nil }))
+	err := ctr.Contribute(newTestConstructor(func() (int, kdone.Destructor, error) { return 0, kdone.Noop, nil }))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer__EmptyGroup(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustDeclareGroup(Group(reflect.TypeOf(0)))
+	ctr.MustDeclareGroup(KeyedGroup(reflect.TypeOf(0)))
+	ctr.MustRun(newTestFunctor(func(group []int, keyed map[string]int) ([]Functor, error) {
+		if group == nil || len(group) != 0 || keyed == nil || len(keyed) != 0 {
+			return nil, kerror.Newf(kerror.EInvalid, "empty groups expected, %v and %v given", group, keyed)
+		}
+		return nil, nil
+	}))
+}
+
+func TestContainer_DeclareGroup__WrongType(t *testing.T) {
+	err := NewContainer().DeclareGroup(reflect.TypeOf(map[int]int{}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_DeclareGroup__AmbiguousConstructor(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() ([]int, kdone.Destructor, error) { return nil, kdone.Noop, nil }))
+	err := ctr.DeclareGroup(reflect.TypeOf([]int{}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Contribute__ScopedConstructor(t *testing.T) {
+	ctr := NewContainer()
+	err := ctr.Contribute(newTestScopedConstructor(Singleton, func() (int, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+	err = ctr.ProvideInto("key", newTestScopedConstructor(Transient, func() (int, kdone.Destructor, error) {
+		return 0, kdone.Noop, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Provide__AmbiguousGroup(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustContribute(newTestConstructor(func() (int, kdone.Destructor, error) { return 0, kdone.Noop, nil }))
+	err := ctr.Provide(newTestConstructor(func() ([]int, kdone.Destructor, error) { return nil, kdone.Noop, nil }))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Members(t *testing.T) {
+	member1 := newTestConstructor(func() (int, kdone.Destructor, error) { return 1, kdone.Noop, nil })
+	member2 := newTestConstructor(func() (int, kdone.Destructor, error) { return 2, kdone.Noop, nil })
+	ctr := NewContainer()
+	ctr.MustContribute(member1)
+	ctr.MustContribute(member2)
+	if mm := ctr.Members(reflect.TypeOf([]int{})); len(mm) != 2 || mm[0].Constructor != member1 || mm[1].Constructor != member2 {
+		t.Fail()
+		return
+	}
+	if mm := ctr.Members(reflect.TypeOf(0)); len(mm) != 0 {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_ExploreGroups(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustContribute(newTestConstructor(func() (int32, kdone.Destructor, error) { return 0, kdone.Noop, nil }))
+	ctr.MustContribute(newTestConstructor(func() (int64, kdone.Destructor, error) { return 0, kdone.Noop, nil }))
+	ctr.MustAttach(newTestProcessor(func([]int64) error { return nil }))
+	c := 0
+	ctr.ExploreGroups(func(gt reflect.Type, members []Member, processors []Processor) (next bool) {
+		c++
+		switch gt {
+		default:
+			t.Logf("%s", gt)
+			t.Fail()
+		case reflect.TypeOf([]int32{}):
+			if len(members) != 1 || len(processors) != 0 {
+				t.Fail()
+			}
+		case reflect.TypeOf([]int64{}):
+			if len(members) != 1 || len(processors) != 1 {
+				t.Fail()
+			}
+		}
+		return true
+	})
+	if c != 2 {
+		t.Fail()
+		return
+	}
+	ctr.Explore(func(rt reflect.Type, ctor Constructor, processors []Processor) (next bool) {
+		t.Logf("%s", rt)
+		t.Fail()
+		return true
+	})
+}
+
+func TestContainer_Run__ErrorProneGroupMember(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustContribute(newTestConstructor(func() (int, kdone.Destructor, error) {
+		return 0, kdone.Noop, kerror.New(kerror.Label("test.Error"), "test error")
+	}))
+	err := ctr.Run(newTestFunctor(func([]int) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.Label("test.Error") {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_Contribute(t *testing.T) {
+	err := (*Container)(nil).Contribute(newTestConstructor(newTestObject1))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_ProvideInto(t *testing.T) {
+	err := (*Container)(nil).ProvideInto("one", newTestConstructor(newTestObject1))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_Members(t *testing.T) {
+	if members := (*Container)(nil).Members(reflect.TypeOf([]int{})); len(members) > 0 {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_DeclareGroup(t *testing.T) {
+	err := (*Container)(nil).DeclareGroup(reflect.TypeOf([]int{}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
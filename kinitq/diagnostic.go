@@ -0,0 +1,302 @@
+package kinitq
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// CycleError represents an error indicating a cyclic dependency.
+type CycleError struct {
+	// Path specifies types forming the cycle. The first and the last types are the same.
+	Path []reflect.Type
+	// Locations specifies source locations of constructors of types
+	// from the Path (the empty string means an unknown location).
+	Locations []string
+}
+
+// newCycleError returns a new cycle error for the given path.
+func newCycleError(ctr *kinit.Container, path []reflect.Type) *CycleError {
+	e := &CycleError{
+		Path:      path,
+		Locations: make([]string, len(path)),
+	}
+	for j, t := range path {
+		ctor, _ := ctr.Lookup(t)
+		e.Locations[j] = locationOf(ctor)
+	}
+	return e
+}
+
+// Class returns the class of this error.
+func (e *CycleError) Class() kerror.Class {
+	return kerror.EAmbiguous
+}
+
+// Error implements the error interface.
+func (e *CycleError) Error() string {
+	var locations []string
+	if n := len(e.Locations); n > 0 {
+		// The last location duplicates the first one.
+		locations = e.Locations[:n-1]
+	}
+	return "cyclic dependency: " + joinTypes(e.Path) + joinLocations(locations)
+}
+
+// UnsatisfiedError represents an error indicating an unsatisfied dependency.
+type UnsatisfiedError struct {
+	// Dependent specifies the type which dependency is unsatisfied (nil for required types).
+	Dependent reflect.Type
+	// Missing specifies the type of the unsatisfied dependency.
+	Missing reflect.Type
+	// Location specifies the source location of the constructor, processor or functor
+	// which dependency is unsatisfied (the empty string means an unknown location).
+	Location string
+}
+
+// newUnsatisfiedError returns a new unsatisfied error.
+func newUnsatisfiedError(dependent, missing reflect.Type, owner interface{}) *UnsatisfiedError {
+	return &UnsatisfiedError{
+		Dependent: dependent,
+		Missing:   missing,
+		Location:  locationOf(owner),
+	}
+}
+
+// Class returns the class of this error.
+func (e *UnsatisfiedError) Class() kerror.Class {
+	return kerror.ENotFound
+}
+
+// Error implements the error interface.
+func (e *UnsatisfiedError) Error() string {
+	if e.Dependent == nil {
+		return "unsatisfied dependency: " + e.Missing.String() + joinLocations([]string{e.Location})
+	}
+	return "unsatisfied dependency: " + joinTypes([]reflect.Type{e.Dependent, e.Missing}) +
+		joinLocations([]string{e.Location})
+}
+
+// IrrelevantProcessorError represents an error indicating the presence
+// of processors for a type without constructor.
+type IrrelevantProcessorError struct {
+	// Type specifies the type of objects processed by irrelevant processors.
+	Type reflect.Type
+	// Locations specifies source locations of irrelevant processors
+	// (the empty string means an unknown location).
+	Locations []string
+}
+
+// newIrrelevantProcessorError returns a new irrelevant processor error.
+func newIrrelevantProcessorError(t reflect.Type, processors []kinit.Processor) *IrrelevantProcessorError {
+	e := &IrrelevantProcessorError{
+		Type:      t,
+		Locations: make([]string, len(processors)),
+	}
+	for j, proc := range processors {
+		e.Locations[j] = locationOf(proc)
+	}
+	return e
+}
+
+// Class returns the class of this error.
+func (e *IrrelevantProcessorError) Class() kerror.Class {
+	return kerror.EInvalid
+}
+
+// Error implements the error interface.
+func (e *IrrelevantProcessorError) Error() string {
+	return e.Type.String() + " processor(s) found in absence of constructor" + joinLocations(e.Locations)
+}
+
+// UnusedError represents an error indicating constructors, group members or processors
+// of the same type unreachable from required types.
+type UnusedError struct {
+	// Kind specifies the kind of unused entities: ConstructorNode, GroupNode or ProcessorNode.
+	Kind NodeKind
+	// Type specifies the type of objects created or processed by unused entities.
+	Type reflect.Type
+	// Locations specifies source locations of unused entities (the empty string means an unknown location).
+	Locations []string
+}
+
+// newUnusedError returns a new unused error.
+func newUnusedError(kind NodeKind, t reflect.Type, entities []interface{}) *UnusedError {
+	e := &UnusedError{
+		Kind:      kind,
+		Type:      t,
+		Locations: make([]string, len(entities)),
+	}
+	for j, x := range entities {
+		e.Locations[j] = locationOf(x)
+	}
+	return e
+}
+
+// Class returns the class of this error.
+func (e *UnusedError) Class() kerror.Class {
+	return kerror.EInvalid
+}
+
+// Error implements the error interface.
+func (e *UnusedError) Error() string {
+	var s string
+	switch e.Kind {
+	default:
+		s = e.Type.String() + " constructor is unused"
+	case GroupNode:
+		s = e.Type.String() + " group is unused"
+	case ProcessorNode:
+		s = e.Type.String() + " processor(s) are unused"
+	}
+	return s + joinLocations(e.Locations)
+}
+
+// CaptiveError represents an error indicating a captive dependency: objects of the kinit.Singleton scope
+// depend (maybe through unscoped objects) on objects of a named scope which live shorter.
+type CaptiveError struct {
+	// Path specifies the dependency chain from the type of singleton objects to the type of captured ones.
+	Path []reflect.Type
+	// Scope specifies the scope of captured objects.
+	Scope kinit.Scope
+	// Locations specifies source locations of constructors of types
+	// from the Path (the empty string means an unknown location).
+	Locations []string
+}
+
+// newCaptiveError returns a new captive error for the given path.
+func newCaptiveError(ctr *kinit.Container, path []reflect.Type, scope kinit.Scope) *CaptiveError {
+	e := &CaptiveError{
+		Path:      path,
+		Scope:     scope,
+		Locations: make([]string, len(path)),
+	}
+	for j, t := range path {
+		ctor, _ := ctr.Lookup(t)
+		e.Locations[j] = locationOf(ctor)
+	}
+	return e
+}
+
+// Class returns the class of this error.
+func (e *CaptiveError) Class() kerror.Class {
+	return kerror.EIllegal
+}
+
+// Error implements the error interface.
+func (e *CaptiveError) Error() string {
+	return "captive dependency: " + joinTypes(e.Path) + " of " + strconv.Quote(string(e.Scope)) + " scope" +
+		joinLocations(e.Locations)
+}
+
+// locationOf returns the source location of the given constructor, processor or functor
+// if it implements the kinit.Describer interface, or the empty string otherwise.
+//
+// The location of the underlying function is preferred to the location of the entity creation.
+func locationOf(x interface{}) string {
+	d := kinit.Describe(x)
+	if d.Location != "" {
+		return d.Location
+	}
+	return d.Caller
+}
+
+// joinTypes returns the string representation of the given dependency chain.
+func joinTypes(types []reflect.Type) string {
+	ss := make([]string, len(types))
+	for j, t := range types {
+		ss[j] = t.String()
+	}
+	return strings.Join(ss, " 🠖 ")
+}
+
+// joinLocations returns the string representation of known locations from the given ones
+// in parentheses prefixed by a space or the empty string if all locations are unknown.
+func joinLocations(locations []string) string {
+	var known []string
+	for _, l := range locations {
+		if l != "" {
+			known = append(known, l)
+		}
+	}
+	if len(known) == 0 {
+		return ""
+	}
+	return " (at " + strings.Join(known, ", ") + ")"
+}
+
+// jsonDiagnostic represents the JSON form of an inspection diagnostic.
+type jsonDiagnostic struct {
+	Kind      string   `json:"kind"`
+	Class     string   `json:"class,omitempty"`
+	Message   string   `json:"message"`
+	Types     []string `json:"types,omitempty"`
+	Locations []string `json:"locations,omitempty"`
+}
+
+// WriteJSONReport writes the report on the given inspection error to the given writer in the JSON format.
+//
+// The report is an array of diagnostics (empty when the error is nil). Each diagnostic has the kind
+// ("cycle", "unsatisfied", "irrelevant", "unused", "captive" or "error" for untyped errors), the class and the message
+// of an error along with involved types and source locations of involved entities when known.
+func WriteJSONReport(w io.Writer, err error) error {
+	diagnostics := []jsonDiagnostic{}
+	coerr := kerror.NewCollector()
+	coerr.Collect(err)
+	if errs, ok := coerr.Error().(kerror.MultiError); ok {
+		for _, err := range errs {
+			diagnostics = append(diagnostics, newJSONDiagnostic(err))
+		}
+	} else if err != nil {
+		diagnostics = append(diagnostics, newJSONDiagnostic(err))
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(diagnostics)
+}
+
+// newJSONDiagnostic returns the JSON form of the given error.
+func newJSONDiagnostic(err error) jsonDiagnostic {
+	d := jsonDiagnostic{
+		Kind:    "error",
+		Message: err.Error(),
+	}
+	if class := kerror.ClassOf(err); class != nil {
+		d.Class = class.ErrorClass()
+	}
+	var types []reflect.Type
+	switch e := err.(type) {
+	case *CycleError:
+		d.Kind = "cycle"
+		types = e.Path
+		d.Locations = e.Locations
+	case *UnsatisfiedError:
+		d.Kind = "unsatisfied"
+		if e.Dependent != nil {
+			types = append(types, e.Dependent)
+		}
+		types = append(types, e.Missing)
+		d.Locations = []string{e.Location}
+	case *IrrelevantProcessorError:
+		d.Kind = "irrelevant"
+		types = []reflect.Type{e.Type}
+		d.Locations = e.Locations
+	case *UnusedError:
+		d.Kind = "unused"
+		types = []reflect.Type{e.Type}
+		d.Locations = e.Locations
+	case *CaptiveError:
+		d.Kind = "captive"
+		types = e.Path
+		d.Locations = e.Locations
+	}
+	for _, t := range types {
+		d.Types = append(d.Types, t.String())
+	}
+	return d
+}
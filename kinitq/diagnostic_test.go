@@ -0,0 +1,213 @@
+package kinitq
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testLocatedConstructor struct {
+	*testConstructor
+	location string
+}
+
+func (c testLocatedConstructor) Describe() kinit.Description {
+	return kinit.Description{Location: c.location}
+}
+
+func TestInspector__CycleError(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(testLocatedConstructor{newTestConstructor(func(int32) int16 { return 0 }), "a.go:1"})
+	ctr.MustProvide(testLocatedConstructor{newTestConstructor(func(int16) int32 { return 0 }), "b.go:2"})
+	inspector := NewInspector()
+	inspector.MustRequire(reflect.TypeOf(int16(0)))
+	err := inspector.Inspect(ctr, &Options{InspectOnlyRequired: true})
+	t.Logf("%+v", err)
+	e, ok := err.(*CycleError)
+	if !ok {
+		t.Fail()
+		return
+	}
+	i16, i32 := reflect.TypeOf(int16(0)), reflect.TypeOf(int32(0))
+	if !reflect.DeepEqual(e.Path, []reflect.Type{i16, i32, i16}) ||
+		!reflect.DeepEqual(e.Locations, []string{"a.go:1", "b.go:2", "a.go:1"}) {
+		t.Fail()
+		return
+	}
+	if e.Error() != "cyclic dependency: int16 🠖 int32 🠖 int16 (at a.go:1, b.go:2)" {
+		t.Fail()
+		return
+	}
+}
+
+type testScopedConstructor struct {
+	testLocatedConstructor
+	scope kinit.Scope
+}
+
+func (c testScopedConstructor) Scope() kinit.Scope {
+	return c.scope
+}
+
+func TestInspector__CaptiveError(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(testScopedConstructor{
+		testLocatedConstructor{newTestConstructor(func(int32) int16 { return 0 }), "a.go:1"},
+		kinit.Singleton,
+	})
+	ctr.MustProvide(testLocatedConstructor{newTestConstructor(func(int64) int32 { return 0 }), "b.go:2"})
+	ctr.MustProvide(testScopedConstructor{
+		testLocatedConstructor{newTestConstructor(func() int64 { return 0 }), "c.go:3"},
+		"request",
+	})
+	ctr.MustProvide(testScopedConstructor{
+		testLocatedConstructor{newTestConstructor(func(int64) uint16 { return 0 }), "d.go:4"},
+		"job",
+	})
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	e, ok := err.(*CaptiveError)
+	if !ok {
+		t.Fail()
+		return
+	}
+	i16, i32, i64 := reflect.TypeOf(int16(0)), reflect.TypeOf(int32(0)), reflect.TypeOf(int64(0))
+	if !reflect.DeepEqual(e.Path, []reflect.Type{i16, i32, i64}) || e.Scope != "request" ||
+		!reflect.DeepEqual(e.Locations, []string{"a.go:1", "b.go:2", "c.go:3"}) {
+		t.Fail()
+		return
+	}
+	if e.Error() != `captive dependency: int16 🠖 int32 🠖 int64 of "request" scope (at a.go:1, b.go:2, c.go:3)` {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__CaptiveErrorThroughTransient(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(testScopedConstructor{
+		testLocatedConstructor{newTestConstructor(func(int32) int16 { return 0 }), "a.go:1"},
+		kinit.Singleton,
+	})
+	ctr.MustProvide(testScopedConstructor{
+		testLocatedConstructor{newTestConstructor(func(int64) int32 { return 0 }), "b.go:2"},
+		kinit.Transient,
+	})
+	ctr.MustProvide(testScopedConstructor{
+		testLocatedConstructor{newTestConstructor(func() int64 { return 0 }), "c.go:3"},
+		"request",
+	})
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if e, ok := err.(*CaptiveError); !ok || len(e.Path) != 3 {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__UnsatisfiedError(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(testLocatedConstructor{newTestConstructor(func(string) int16 { return 0 }), "a.go:1"})
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	e, ok := err.(*UnsatisfiedError)
+	if !ok {
+		t.Fail()
+		return
+	}
+	if e.Dependent != reflect.TypeOf(int16(0)) || e.Missing != reflect.TypeOf("") || e.Location != "a.go:1" {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__UnsatisfiedRequiredError(t *testing.T) {
+	inspector := NewInspector()
+	inspector.MustRequire(reflect.TypeOf(""))
+	err := inspector.Inspect(kinit.NewContainer(), nil)
+	t.Logf("%+v", err)
+	e, ok := err.(*UnsatisfiedError)
+	if !ok || e.Dependent != nil || e.Missing != reflect.TypeOf("") {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__IrrelevantProcessorError(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustAttach(newTestProcessor(func(int64) {}))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	e, ok := err.(*IrrelevantProcessorError)
+	if !ok || e.Type != reflect.TypeOf(int64(0)) || len(e.Locations) != 1 {
+		t.Fail()
+		return
+	}
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(testLocatedConstructor{newTestConstructor(func(int32) int16 { return 0 }), "a.go:1"})
+	ctr.MustProvide(newTestConstructor(func(int16) int32 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(string) int64 { return 0 }))
+	ctr.MustAttach(newTestProcessor(func(uint64) {}))
+	inspector := NewInspector()
+	err := inspector.Inspect(ctr, nil)
+	var buf bytes.Buffer
+	if err := WriteJSONReport(&buf, kerror.Join(err, kerror.New(kerror.ERuntime, "test error"))); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	t.Log(buf.String())
+	var diagnostics []struct {
+		Kind      string   `json:"kind"`
+		Class     string   `json:"class"`
+		Message   string   `json:"message"`
+		Types     []string `json:"types"`
+		Locations []string `json:"locations"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &diagnostics); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	kinds := make(map[string]int)
+	for _, d := range diagnostics {
+		kinds[d.Kind]++
+		if d.Kind == "cycle" && len(d.Types) != 3 {
+			t.Fail()
+			return
+		}
+		if d.Kind == "error" && d.Class != string(kerror.ERuntime) {
+			t.Fail()
+			return
+		}
+	}
+	if !reflect.DeepEqual(kinds, map[string]int{"cycle": 1, "unsatisfied": 1, "irrelevant": 1, "error": 1}) {
+		t.Logf("%v", kinds)
+		t.Fail()
+		return
+	}
+}
+
+func TestWriteJSONReport__Nil(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSONReport(&buf, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if buf.String() != "[]\n" {
+		t.Fail()
+		return
+	}
+}
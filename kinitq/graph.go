@@ -0,0 +1,389 @@
+package kinitq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// NodeKind represents a kind of a dependency graph node.
+type NodeKind string
+
+const (
+	// ConstructorNode specifies the kind of nodes representing constructors.
+	ConstructorNode NodeKind = "constructor"
+	// GroupNode specifies the kind of nodes representing groups (plain or keyed).
+	GroupNode NodeKind = "group"
+	// MemberNode specifies the kind of nodes representing group members.
+	MemberNode NodeKind = "member"
+	// ProcessorNode specifies the kind of nodes representing processors.
+	ProcessorNode NodeKind = "processor"
+	// FunctorNode specifies the kind of nodes representing considered functors.
+	FunctorNode NodeKind = "functor"
+	// IgnoredNode specifies the kind of nodes representing ignored types.
+	IgnoredNode NodeKind = "ignored"
+	// MissingNode specifies the kind of nodes representing types that have no constructor.
+	MissingNode NodeKind = "missing"
+)
+
+// EdgeKind represents a kind of a dependency graph edge.
+type EdgeKind string
+
+const (
+	// ParameterEdge specifies the kind of edges leading from an entity to the provider of its parameter.
+	ParameterEdge EdgeKind = "parameter"
+	// ProcessEdge specifies the kind of edges leading from a processor to the provider of an object it processes.
+	ProcessEdge EdgeKind = "process"
+	// MemberEdge specifies the kind of edges leading from a group to its member.
+	MemberEdge EdgeKind = "member"
+	// LazyEdge specifies the kind of edges leading from an entity to the provider of its lazy parameter.
+	LazyEdge EdgeKind = "lazy"
+	// OptionalEdge specifies the kind of edges leading from an entity to the provider of its optional parameter.
+	//
+	// Such edges are absent if there is no provider of an optional parameter.
+	OptionalEdge EdgeKind = "optional"
+)
+
+// Node represents a dependency graph node.
+type Node struct {
+	// ID specifies the identifier of this node unique within the graph.
+	ID string
+	// Kind specifies the kind of this node.
+	Kind NodeKind
+	// Type specifies the type of an object created or processed by the entity this node represents
+	// (nil for functors).
+	Type reflect.Type
+	// Key specifies the key of a keyed group member.
+	Key string
+	// Label specifies the human-readable label of this node.
+	Label string
+}
+
+// Edge represents a dependency graph edge.
+type Edge struct {
+	// From specifies the identifier of the dependent node.
+	From string
+	// To specifies the identifier of the node the dependent one depends on.
+	To string
+	// Kind specifies the kind of this edge.
+	Kind EdgeKind
+	// Type specifies the type of a dependency this edge represents.
+	Type reflect.Type
+}
+
+// Graph represents a dependency graph of a container.
+type Graph struct {
+	// Nodes specifies graph nodes.
+	Nodes []*Node
+	// Edges specifies graph edges.
+	Edges []*Edge
+}
+
+// graphBuilder represents a dependency graph builder.
+type graphBuilder struct {
+	// graph specifies the graph being built.
+	graph *Graph
+	// providers specifies nodes of constructors and groups associated with types of objects they provide.
+	providers map[reflect.Type]*Node
+	// members specifies nodes of group members associated with types of objects they create.
+	members map[reflect.Type][]*Node
+	// stubs specifies nodes of ignored and missing types.
+	stubs map[reflect.Type]*Node
+}
+
+// addNode adds a new node to the graph being built.
+func (b *graphBuilder) addNode(kind NodeKind, t reflect.Type, label string) *Node {
+	node := &Node{
+		ID:    "n" + strconv.Itoa(len(b.graph.Nodes)+1),
+		Kind:  kind,
+		Type:  t,
+		Label: label,
+	}
+	b.graph.Nodes = append(b.graph.Nodes, node)
+	return node
+}
+
+// addEdge adds a new edge to the graph being built.
+func (b *graphBuilder) addEdge(from, to *Node, kind EdgeKind, t reflect.Type) {
+	b.graph.Edges = append(b.graph.Edges, &Edge{
+		From: from.ID,
+		To:   to.ID,
+		Kind: kind,
+		Type: t,
+	})
+}
+
+// provider returns the node providing objects of the given type.
+// The stub node will be added if there is no such node.
+func (b *graphBuilder) provider(t reflect.Type, ignored bool) *Node {
+	if node, ok := b.providers[t]; ok {
+		return node
+	}
+	if node, ok := b.stubs[t]; ok {
+		return node
+	}
+	kind := MissingNode
+	if ignored {
+		kind = IgnoredNode
+	}
+	node := b.addNode(kind, t, t.String())
+	b.stubs[t] = node
+	return node
+}
+
+// Graph returns the dependency graph of the given container.
+//
+// Graph nodes represent constructors, groups, group members, processors and functors
+// registered via the Consider. Graph edges lead from dependent nodes to nodes they depend on.
+// Types that have no constructor are represented by stub nodes.
+func (i *Inspector) Graph(ctr *kinit.Container) (*Graph, error) {
+	if i == nil {
+		return nil, kerror.New(kerror.ENil, "nil inspector cannot build graph")
+	}
+	if ctr == nil {
+		return nil, kerror.New(kerror.EInvalid, "inspector cannot build graph of nil container")
+	}
+	b := &graphBuilder{
+		graph:     &Graph{},
+		providers: make(map[reflect.Type]*Node),
+		members:   make(map[reflect.Type][]*Node),
+		stubs:     make(map[reflect.Type]*Node),
+	}
+	type entry struct {
+		t          reflect.Type
+		ctor       kinit.Constructor
+		members    []kinit.Member
+		processors []kinit.Processor
+	}
+	var entries []entry
+	ctr.Explore(func(t reflect.Type, ctor kinit.Constructor, processors []kinit.Processor) (next bool) {
+		entries = append(entries, entry{t: t, ctor: ctor, processors: processors})
+		return true
+	})
+	ctr.ExploreGroups(func(t reflect.Type, members []kinit.Member, processors []kinit.Processor) (next bool) {
+		entries = append(entries, entry{t: t, members: members, processors: processors})
+		return true
+	})
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].t.String() < entries[b].t.String()
+	})
+	type dependent struct {
+		node       *Node
+		parameters []reflect.Type
+	}
+	var dependents []dependent
+	for _, e := range entries {
+		if e.ctor != nil {
+			node := b.addNode(ConstructorNode, e.t, e.t.String())
+			b.providers[e.t] = node
+			dependents = append(dependents, dependent{node, e.ctor.Parameters()})
+		}
+		if len(e.members) > 0 {
+			group := b.addNode(GroupNode, e.t, e.t.String())
+			b.providers[e.t] = group
+			for j, member := range e.members {
+				mt := member.Constructor.Type()
+				label := mt.String()
+				if member.Key != "" {
+					label += "[" + strconv.Quote(member.Key) + "]"
+				} else {
+					label += "[" + strconv.Itoa(j) + "]"
+				}
+				node := b.addNode(MemberNode, mt, label)
+				node.Key = member.Key
+				b.members[mt] = append(b.members[mt], node)
+				b.addEdge(group, node, MemberEdge, mt)
+				dependents = append(dependents, dependent{node, member.Constructor.Parameters()})
+			}
+		}
+	}
+	for _, e := range entries {
+		for _, proc := range e.processors {
+			label := "process " + e.t.String()
+			if sequencer, ok := proc.(kinit.Sequencer); ok && sequencer.Label() != "" {
+				label += " (" + sequencer.Label() + ")"
+			}
+			node := b.addNode(ProcessorNode, e.t, label)
+			if provider, ok := b.providers[e.t]; ok {
+				b.addEdge(node, provider, ProcessEdge, e.t)
+			}
+			for _, member := range b.members[e.t] {
+				b.addEdge(node, member, ProcessEdge, e.t)
+			}
+			dependents = append(dependents, dependent{node, proc.Parameters()})
+		}
+	}
+	for _, fun := range i.functors {
+		parameters := fun.Parameters()
+		names := make([]string, len(parameters))
+		for j, t := range parameters {
+			names[j] = fmt.Sprint(t)
+		}
+		node := b.addNode(FunctorNode, nil, "run("+strings.Join(names, ", ")+")")
+		dependents = append(dependents, dependent{node, parameters})
+	}
+	for _, d := range dependents {
+		for _, t := range d.parameters {
+			if t == nil {
+				continue
+			}
+			if target := kinit.LazyTarget(t); target != nil && b.providers[t] == nil && !i.types[t] {
+				b.addEdge(d.node, b.provider(target, i.types[target]), LazyEdge, t)
+				continue
+			}
+			if target := kinit.OptionalTarget(t); target != nil && b.providers[t] == nil && !i.types[t] {
+				if provider, ok := b.providers[target]; ok {
+					b.addEdge(d.node, provider, OptionalEdge, t)
+				}
+				continue
+			}
+			b.addEdge(d.node, b.provider(t, i.types[t]), ParameterEdge, t)
+		}
+	}
+	return b.graph, nil
+}
+
+// MustGraph is a variant of the Graph that panics on error.
+func (i *Inspector) MustGraph(ctr *kinit.Container) *Graph {
+	g, err := i.Graph(ctr)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// WriteDOT writes this graph to the given writer in the Graphviz DOT format.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if g == nil {
+		return kerror.New(kerror.ENil, "nil graph cannot be written")
+	}
+	var sb strings.Builder
+	sb.WriteString("digraph kinit {\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&sb, "\t%s [label=%s %s];\n", node.ID, strconv.Quote(node.Label), dotNodeStyles[node.Kind])
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&sb, "\t%s -> %s [%s];\n", edge.From, edge.To, dotEdgeStyles[edge.Kind])
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// dotNodeStyles specifies DOT attributes of nodes associated with their kinds.
+var dotNodeStyles = map[NodeKind]string{
+	ConstructorNode: `shape=box`,
+	GroupNode:       `shape=box3d`,
+	MemberNode:      `shape=box style=rounded`,
+	ProcessorNode:   `shape=ellipse`,
+	FunctorNode:     `shape=hexagon`,
+	IgnoredNode:     `shape=box style=dashed`,
+	MissingNode:     `shape=box style=dashed color=red`,
+}
+
+// dotEdgeStyles specifies DOT attributes of edges associated with their kinds.
+var dotEdgeStyles = map[EdgeKind]string{
+	ParameterEdge: `style=solid`,
+	ProcessEdge:   `style=dashed`,
+	MemberEdge:    `style=dotted`,
+	LazyEdge:      `style=solid arrowhead=empty`,
+	OptionalEdge:  `style=solid arrowhead=odot`,
+}
+
+// WriteMermaid writes this graph to the given writer in the Mermaid flowchart format.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	if g == nil {
+		return kerror.New(kerror.ENil, "nil graph cannot be written")
+	}
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, node := range g.Nodes {
+		shape := mermaidNodeShapes[node.Kind]
+		label := strings.ReplaceAll(node.Label, `"`, "#quot;")
+		fmt.Fprintf(&sb, "\t%s%c\"%s\"%c\n", node.ID, shape[0], label, shape[1])
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&sb, "\t%s %s %s\n", edge.From, mermaidEdgeArrows[edge.Kind], edge.To)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// mermaidNodeShapes specifies Mermaid brackets of nodes associated with their kinds.
+var mermaidNodeShapes = map[NodeKind][2]rune{
+	ConstructorNode: {'[', ']'},
+	GroupNode:       {'[', ']'},
+	MemberNode:      {'(', ')'},
+	ProcessorNode:   {'(', ')'},
+	FunctorNode:     {'{', '}'},
+	IgnoredNode:     {'>', ']'},
+	MissingNode:     {'>', ']'},
+}
+
+// mermaidEdgeArrows specifies Mermaid arrows of edges associated with their kinds.
+var mermaidEdgeArrows = map[EdgeKind]string{
+	ParameterEdge: "-->",
+	ProcessEdge:   "-.->",
+	MemberEdge:    "---",
+	LazyEdge:      "--o",
+	OptionalEdge:  "--x",
+}
+
+// jsonNode represents the JSON form of a dependency graph node.
+type jsonNode struct {
+	ID    string   `json:"id"`
+	Kind  NodeKind `json:"kind"`
+	Type  string   `json:"type,omitempty"`
+	Key   string   `json:"key,omitempty"`
+	Label string   `json:"label"`
+}
+
+// jsonEdge represents the JSON form of a dependency graph edge.
+type jsonEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+	Type string   `json:"type"`
+}
+
+// WriteJSON writes this graph to the given writer in the JSON format.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	if g == nil {
+		return kerror.New(kerror.ENil, "nil graph cannot be written")
+	}
+	var doc struct {
+		Nodes []jsonNode `json:"nodes"`
+		Edges []jsonEdge `json:"edges"`
+	}
+	doc.Nodes = make([]jsonNode, len(g.Nodes))
+	for j, node := range g.Nodes {
+		doc.Nodes[j] = jsonNode{
+			ID:    node.ID,
+			Kind:  node.Kind,
+			Key:   node.Key,
+			Label: node.Label,
+		}
+		if node.Type != nil {
+			doc.Nodes[j].Type = node.Type.String()
+		}
+	}
+	doc.Edges = make([]jsonEdge, len(g.Edges))
+	for j, edge := range g.Edges {
+		doc.Edges[j] = jsonEdge{
+			From: edge.From,
+			To:   edge.To,
+			Kind: edge.Kind,
+			Type: edge.Type.String(),
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(doc)
+}
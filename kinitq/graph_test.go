@@ -0,0 +1,225 @@
+package kinitq
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+func newTestGraph() *Graph {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(string) int32 { return 0 }))
+	ctr.MustContribute(newTestConstructor(func(int32) int64 { return 0 }))
+	ctr.MustContribute(newTestConstructor(func() int64 { return 0 }))
+	ctr.MustAttach(newTestProcessor(func(int64, uint8) {}))
+	ctr.MustProvide(newTestConstructor(func([]int64) uint64 { return 0 }))
+	inspector := NewInspector()
+	inspector.MustIgnore(reflect.TypeOf(""))
+	inspector.MustConsider(newTestFunctor(func(uint64) {}))
+	return inspector.MustGraph(ctr)
+}
+
+func TestInspector_Graph(t *testing.T) {
+	g := newTestGraph()
+	kinds := make(map[NodeKind]int)
+	nodes := make(map[string]*Node)
+	for _, node := range g.Nodes {
+		t.Logf("%s %s %s", node.ID, node.Kind, node.Label)
+		kinds[node.Kind]++
+		nodes[node.ID] = node
+	}
+	expected := map[NodeKind]int{
+		ConstructorNode: 2,
+		GroupNode:       1,
+		MemberNode:      2,
+		ProcessorNode:   1,
+		FunctorNode:     1,
+		IgnoredNode:     1,
+		MissingNode:     1,
+	}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Logf("%v", kinds)
+		t.Fail()
+		return
+	}
+	edges := make(map[string]int)
+	for _, edge := range g.Edges {
+		from, to := nodes[edge.From], nodes[edge.To]
+		if from == nil || to == nil {
+			t.Fail()
+			return
+		}
+		t.Logf("%s -%s-> %s", from.Label, edge.Kind, to.Label)
+		edges[string(from.Kind)+" "+string(edge.Kind)+" "+string(to.Kind)]++
+	}
+	expectedEdges := map[string]int{
+		"constructor parameter ignored": 1,
+		"constructor parameter group":   1,
+		"member parameter constructor":  1,
+		"group member member":           2,
+		"processor process member":      2,
+		"processor parameter missing":   1,
+		"functor parameter constructor": 1,
+	}
+	for key, n := range expectedEdges {
+		if edges[key] != n {
+			t.Logf("%s: %d expected, %d given", key, n, edges[key])
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestInspector_Graph__Lazy(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(func() (int64, error)) int32 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(int32) int64 { return 0 }))
+	g := NewInspector().MustGraph(ctr)
+	if len(g.Nodes) != 2 || len(g.Edges) != 2 {
+		t.Logf("%d nodes, %d edges", len(g.Nodes), len(g.Edges))
+		t.Fail()
+		return
+	}
+	for _, edge := range g.Edges {
+		if edge.Kind == LazyEdge {
+			if edge.Type != kinit.Lazy(reflect.TypeOf(int64(0))) {
+				t.Fail()
+			}
+			return
+		}
+	}
+	t.Fail()
+}
+
+func TestInspector_Graph__Optional(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(struct {
+		Value   int64
+		Present bool
+	}) int32 {
+		return 0
+	}))
+	ctr.MustProvide(newTestConstructor(func(struct {
+		Value   string
+		Present bool
+	}) int64 {
+		return 0
+	}))
+	g := NewInspector().MustGraph(ctr)
+	if len(g.Nodes) != 2 || len(g.Edges) != 1 || g.Edges[0].Kind != OptionalEdge {
+		t.Logf("%d nodes, %d edges", len(g.Nodes), len(g.Edges))
+		t.Fail()
+		return
+	}
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestGraph().WriteDOT(&buf); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	s := buf.String()
+	t.Log(s)
+	if !strings.HasPrefix(s, "digraph kinit {") || !strings.Contains(s, `label="[]int64"`) ||
+		!strings.Contains(s, " -> ") {
+		t.Fail()
+		return
+	}
+}
+
+func TestGraph_WriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestGraph().WriteMermaid(&buf); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	s := buf.String()
+	t.Log(s)
+	if !strings.HasPrefix(s, "flowchart LR") || !strings.Contains(s, `["[]int64"]`) ||
+		!strings.Contains(s, " --> ") {
+		t.Fail()
+		return
+	}
+}
+
+func TestGraph_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestGraph().WriteJSON(&buf); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	t.Log(buf.String())
+	var doc struct {
+		Nodes []struct {
+			ID   string `json:"id"`
+			Kind string `json:"kind"`
+		} `json:"nodes"`
+		Edges []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"edges"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if len(doc.Nodes) != 9 || len(doc.Edges) != 9 {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector_Graph__NilContainer(t *testing.T) {
+	_, err := NewInspector().Graph(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilInspector_Graph(t *testing.T) {
+	_, err := (*Inspector)(nil).Graph(kinit.NewContainer())
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilGraph_WriteDOT(t *testing.T) {
+	err := (*Graph)(nil).WriteDOT(&bytes.Buffer{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilGraph_WriteMermaid(t *testing.T) {
+	err := (*Graph)(nil).WriteMermaid(&bytes.Buffer{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilGraph_WriteJSON(t *testing.T) {
+	err := (*Graph)(nil).WriteJSON(&bytes.Buffer{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
@@ -1,7 +1,9 @@
 package kinitq
 
 import (
+	"context"
 	"reflect"
+	"sort"
 
 	"github.com/go-kata/kerror"
 	"github.com/go-kata/kinit"
@@ -16,12 +18,18 @@ type Inspector struct {
 	// and false means it must be inspected that dependencies of this type can
 	// be successfully satisfied.
 	types map[reflect.Type]bool
+	// functors specifies considered functors.
+	functors []kinit.Functor
 }
 
 // NewInspector returns a new inspector.
+//
+// The context.Context type is ignored by default since the container provides it on each run.
 func NewInspector() *Inspector {
 	return &Inspector{
-		types: make(map[reflect.Type]bool),
+		types: map[reflect.Type]bool{
+			reflect.TypeOf((*context.Context)(nil)).Elem(): true,
+		},
 	}
 }
 
@@ -46,6 +54,31 @@ func (i *Inspector) MustRequire(t reflect.Type) {
 	}
 }
 
+// Consider registers the given functor in this inspector
+// by requiring types of all its dependencies.
+func (i *Inspector) Consider(fun kinit.Functor) error {
+	if i == nil {
+		return kerror.New(kerror.ENil, "nil inspector cannot register functor")
+	}
+	if fun == nil {
+		return kerror.New(kerror.EInvalid, "inspector cannot register nil functor")
+	}
+	for _, t := range fun.Parameters() {
+		if err := i.Require(t); err != nil {
+			return err
+		}
+	}
+	i.functors = append(i.functors, fun)
+	return nil
+}
+
+// MustConsider us a variant of the Consider that panics on error.
+func (i *Inspector) MustConsider(fun kinit.Functor) {
+	if err := i.Consider(fun); err != nil {
+		panic(err)
+	}
+}
+
 // Ignore registers the given ignored type in this inspector.
 func (i *Inspector) Ignore(t reflect.Type) error {
 	if i == nil {
@@ -75,6 +108,11 @@ type Options struct {
 	//
 	// This option only applies if the InspectOnlyRequired is false.
 	AllowIrrelevantProcessors bool
+	// ReportUnused specifies whether to report constructors, groups and processors
+	// unreachable from required types (including dependencies of considered functors).
+	//
+	// Ignored types are never reported, so the Ignore may be used as an allowlist.
+	ReportUnused bool
 }
 
 // background represents an inspection background.
@@ -87,9 +125,24 @@ type background struct {
 	history map[reflect.Type]bool
 	// stack specifies the inspection stack.
 	stack []reflect.Type
+	// lazy specifies lazy dependencies postponed to be inspected outside the inspection stack.
+	lazy []lazyDependency
+	// ignored specifies types reachable from ignored types. Such types are not inspected
+	// through ignored ones, but they are not reported as unused.
+	ignored map[reflect.Type]bool
+}
+
+// lazyDependency represents a lazy dependency postponed to be inspected.
+type lazyDependency struct {
+	// owner specifies the constructor, processor or functor the dependency belongs to.
+	owner interface{}
+	// dependent specifies the type of dependent objects (nil for functors and required types).
+	dependent reflect.Type
+	// target specifies the type of objects the lazy dependency resolves to.
+	target reflect.Type
 }
 
-// Inspect inspects the given container for the absence of cyclic and unsatisfied dependencies.
+// Inspect inspects the given container for the absence of cyclic, unsatisfied and captive dependencies.
 func (i *Inspector) Inspect(ctr *kinit.Container, opt *Options) error {
 	if i == nil {
 		return nil
@@ -100,28 +153,54 @@ func (i *Inspector) Inspect(ctr *kinit.Container, opt *Options) error {
 	coerr := kerror.NewCollector()
 	bg := &background{
 		history: make(map[reflect.Type]bool),
+		ignored: make(map[reflect.Type]bool),
+	}
+	for _, fun := range i.functors {
+		coerr.Collect(i.inspectTypes(ctr, fun, fun.Parameters(), bg))
 	}
 	for t, ignore := range i.types {
 		if ignore {
 			continue
 		}
-		coerr.Collect(i.inspectType(ctr, t, bg))
+		coerr.Collect(i.inspectType(ctr, nil, t, bg))
+	}
+	coerr.Collect(i.inspectLazy(ctr, bg))
+	if opt.ReportUnused {
+		// At this moment the inspection history along with types reachable from ignored ones
+		// contains exactly types reachable from required ones.
+		reachable := make(map[reflect.Type]bool, len(bg.history)+len(bg.ignored))
+		for t := range bg.history {
+			reachable[t] = true
+		}
+		for t := range bg.ignored {
+			reachable[t] = true
+		}
+		coerr.Collect(i.inspectUnused(ctr, reachable))
 	}
 	if !opt.InspectOnlyRequired {
 		ctr.Explore(func(t reflect.Type, ctor kinit.Constructor, processors []kinit.Processor) (next bool) {
 			if ctor != nil {
-				coerr.Collect(i.inspectType(ctr, t, bg))
+				coerr.Collect(i.inspectType(ctr, nil, t, bg))
 				return true
 			}
-			if !opt.AllowIrrelevantProcessors {
-				coerr.Collect(kerror.Newf(kerror.EInvalid, "%s processor(s) found in absence of constructor", t))
+			irrelevant := len(ctr.Members(kinit.Group(t))) == 0 && len(ctr.Members(kinit.KeyedGroup(t))) == 0
+			if !irrelevant {
+				// Processors of group members are inspected along with groups.
+				return true
 			}
-			for _, proc := range processors {
-				coerr.Collect(i.inspectTypes(ctr, proc.Parameters(), bg))
+			if !opt.AllowIrrelevantProcessors {
+				coerr.Collect(newIrrelevantProcessorError(t, processors))
 			}
+			coerr.Collect(i.inspectProcessors(ctr, processors, bg))
+			return true
+		})
+		ctr.ExploreGroups(func(t reflect.Type, members []kinit.Member, processors []kinit.Processor) (next bool) {
+			coerr.Collect(i.inspectType(ctr, nil, t, bg))
 			return true
 		})
+		coerr.Collect(i.inspectLazy(ctr, bg))
 	}
+	coerr.Collect(i.inspectCaptive(ctr, bg.history))
 	return coerr.Error()
 }
 
@@ -132,58 +211,257 @@ func (i *Inspector) MustInspect(ctr *kinit.Container, opt *Options) {
 	}
 }
 
-// inspectType inspects that the dependency of the given type
-// can be successfully satisfied by the given container.
-func (i *Inspector) inspectType(ctr *kinit.Container, t reflect.Type, bg *background) error {
+// inspectUnused inspects that all constructors, groups and processors
+// of the given container are reachable from required types.
+func (i *Inspector) inspectUnused(ctr *kinit.Container, reachable map[reflect.Type]bool) error {
+	var errs []*UnusedError
+	ctr.Explore(func(t reflect.Type, ctor kinit.Constructor, processors []kinit.Processor) (next bool) {
+		if i.types[t] {
+			return true
+		}
+		if ctor != nil {
+			if !reachable[t] {
+				errs = append(errs, newUnusedError(ConstructorNode, t, []interface{}{ctor}))
+			}
+		} else if len(ctr.Members(kinit.Group(t))) == 0 && len(ctr.Members(kinit.KeyedGroup(t))) == 0 {
+			// Irrelevant processors are reported separately.
+			return true
+		}
+		if len(processors) > 0 && !reachable[t] && !reachable[kinit.Group(t)] && !reachable[kinit.KeyedGroup(t)] {
+			entities := make([]interface{}, len(processors))
+			for j, proc := range processors {
+				entities[j] = proc
+			}
+			errs = append(errs, newUnusedError(ProcessorNode, t, entities))
+		}
+		return true
+	})
+	ctr.ExploreGroups(func(t reflect.Type, members []kinit.Member, processors []kinit.Processor) (next bool) {
+		if i.types[t] || reachable[t] {
+			return true
+		}
+		entities := make([]interface{}, len(members))
+		for j, member := range members {
+			entities[j] = member.Constructor
+		}
+		errs = append(errs, newUnusedError(GroupNode, t, entities))
+		if len(processors) > 0 {
+			entities := make([]interface{}, len(processors))
+			for j, proc := range processors {
+				entities[j] = proc
+			}
+			errs = append(errs, newUnusedError(ProcessorNode, t, entities))
+		}
+		return true
+	})
+	sort.SliceStable(errs, func(a, b int) bool {
+		return errs[a].Type.String() < errs[b].Type.String()
+	})
+	coerr := kerror.NewCollector()
+	for _, err := range errs {
+		coerr.Collect(err)
+	}
+	return coerr.Error()
+}
+
+// inspectCaptive inspects that objects of the kinit.Singleton scope created by constructors
+// of given inspected types don't depend on objects of named scopes.
+//
+// Objects of the zero and the kinit.Transient scopes are created along with objects that depend on them,
+// thus dependencies are traced through them. Relations between named scopes are unknown
+// until runtime, so dependencies between objects of different named scopes are never reported.
+func (i *Inspector) inspectCaptive(ctr *kinit.Container, inspected map[reflect.Type]bool) error {
+	var errs []*CaptiveError
+	for t := range inspected {
+		if ctor, _ := ctr.Lookup(t); ctor == nil || kinit.ScopeOf(ctor) != kinit.Singleton {
+			continue
+		}
+		visited := make(map[reflect.Type]bool)
+		var trace func(path []reflect.Type)
+		trace = func(path []reflect.Type) {
+			for _, d := range dependenciesOf(ctr, path[len(path)-1]) {
+				if i.types[d] || visited[d] {
+					continue
+				}
+				visited[d] = true
+				dependency := append(path[:len(path):len(path)], d)
+				ctor, _ := ctr.Lookup(d)
+				switch scope := kinit.ScopeOf(ctor); scope {
+				case "", kinit.Transient:
+					trace(dependency)
+				case kinit.Singleton:
+					// Dependencies of other singleton objects are inspected separately.
+				default:
+					errs = append(errs, newCaptiveError(ctr, dependency, scope))
+				}
+			}
+		}
+		trace([]reflect.Type{t})
+	}
+	sort.SliceStable(errs, func(a, b int) bool {
+		return joinTypes(errs[a].Path) < joinTypes(errs[b].Path)
+	})
+	coerr := kerror.NewCollector()
+	for _, err := range errs {
+		coerr.Collect(err)
+	}
+	return coerr.Error()
+}
+
+// dependenciesOf returns types of dependencies of objects of the given type
+// including dependencies of their processors and of group members.
+//
+// Lazy and optional dependencies are represented by types they resolve to.
+func dependenciesOf(ctr *kinit.Container, t reflect.Type) []reflect.Type {
+	ctor, processors := ctr.Lookup(t)
+	members := ctr.Members(t)
+	if ctor == nil && !ctr.HasGroup(t) {
+		if target := kinit.LazyTarget(t); target != nil {
+			return []reflect.Type{target}
+		}
+		if target := kinit.OptionalTarget(t); target != nil {
+			return []reflect.Type{target}
+		}
+		return nil
+	}
+	var dependencies []reflect.Type
+	if ctor != nil {
+		dependencies = append(dependencies, ctor.Parameters()...)
+	}
+	for _, member := range members {
+		dependencies = append(dependencies, member.Constructor.Parameters()...)
+	}
+	if len(members) > 0 {
+		// All members of a group create objects of the same type.
+		_, pp := ctr.Lookup(members[0].Constructor.Type())
+		processors = append(processors, pp...)
+	}
+	for _, proc := range processors {
+		dependencies = append(dependencies, proc.Parameters()...)
+	}
+	return dependencies
+}
+
+// inspectType inspects that the dependency of the given type of the given owner (constructor,
+// processor, functor or nil for required types) can be successfully satisfied by the given container.
+func (i *Inspector) inspectType(ctr *kinit.Container, owner interface{}, t reflect.Type, bg *background) error {
 	if i.types[t] {
+		markIgnored(ctr, t, bg)
 		return nil
 	}
 	if ended, begun := bg.history[t]; begun {
 		if ended {
 			return nil
 		}
-		s := t.String()
-		n := len(bg.stack)
-		for j := n - 1; j >= 0; j-- {
+		var path []reflect.Type
+		for j := len(bg.stack) - 1; j >= 0; j-- {
 			if bg.stack[j] == t {
-				for k := j + 1; k < n; k++ {
-					s += " 🠖 " + bg.stack[k].String()
-				}
+				path = append(path, bg.stack[j:]...)
 				break
 			}
 		}
-		s += " 🠖 " + t.String()
-		return kerror.Newf(kerror.EAmbiguous, "cyclic dependency: %s", s)
+		return newCycleError(ctr, append(path, t))
 	}
 	bg.history[t] = false
 	defer func() {
 		bg.history[t] = true
 	}()
 	ctor, processors := ctr.Lookup(t)
-	if ctor == nil {
-		s := t.String()
+	members := ctr.Members(t)
+	if ctor == nil && !ctr.HasGroup(t) {
+		var dependent reflect.Type
 		if n := len(bg.stack); n > 0 {
-			s = bg.stack[n-1].String() + " 🠖 " + s
+			dependent = bg.stack[n-1]
+		}
+		if target := kinit.LazyTarget(t); target != nil {
+			// Lazy dependencies are resolved on call, thus they break dependency cycles.
+			bg.lazy = append(bg.lazy, lazyDependency{owner, dependent, target})
+			return nil
 		}
-		return kerror.Newf(kerror.ENotFound, "unsatisfied dependency: %s", s)
+		if target := kinit.OptionalTarget(t); target != nil {
+			if ctor, _ := ctr.Lookup(target); ctor == nil && !ctr.HasGroup(target) {
+				// Optional dependencies are satisfied by the zero value if there is no constructor.
+				return nil
+			}
+			return i.inspectType(ctr, owner, target, bg)
+		}
+		return newUnsatisfiedError(dependent, t, owner)
 	}
 	bg.stack = append(bg.stack, t)
 	defer func() {
 		bg.stack = bg.stack[:len(bg.stack)-1]
 	}()
 	coerr := kerror.NewCollector()
-	coerr.Collect(i.inspectTypes(ctr, ctor.Parameters(), bg))
+	if ctor != nil {
+		coerr.Collect(i.inspectTypes(ctr, ctor, ctor.Parameters(), bg))
+	}
+	coerr.Collect(i.inspectMembers(ctr, members, bg))
+	coerr.Collect(i.inspectProcessors(ctr, processors, bg))
+	return coerr.Error()
+}
+
+// markIgnored marks the given ignored type and types of its dependencies (direct and indirect)
+// as reachable from ignored types without their inspection.
+func markIgnored(ctr *kinit.Container, t reflect.Type, bg *background) {
+	if bg.ignored[t] {
+		return
+	}
+	bg.ignored[t] = true
+	for _, d := range dependenciesOf(ctr, t) {
+		markIgnored(ctr, d, bg)
+	}
+}
+
+// inspectLazy inspects that postponed lazy dependencies can be successfully satisfied by the given container.
+func (i *Inspector) inspectLazy(ctr *kinit.Container, bg *background) error {
+	coerr := kerror.NewCollector()
+	for len(bg.lazy) > 0 {
+		dep := bg.lazy[0]
+		bg.lazy = bg.lazy[1:]
+		if dep.dependent != nil {
+			// The dependent type is already inspected, thus it only makes the unsatisfied dependency error precise.
+			bg.stack = []reflect.Type{dep.dependent}
+		}
+		coerr.Collect(i.inspectType(ctr, dep.owner, dep.target, bg))
+		bg.stack = nil
+	}
+	return coerr.Error()
+}
+
+// inspectMembers inspects that dependencies of given group members
+// can be successfully satisfied by the given container.
+func (i *Inspector) inspectMembers(ctr *kinit.Container, members []kinit.Member, bg *background) error {
+	if len(members) == 0 {
+		return nil
+	}
+	coerr := kerror.NewCollector()
+	for _, member := range members {
+		coerr.Collect(i.inspectTypes(ctr, member.Constructor, member.Constructor.Parameters(), bg))
+	}
+	// All members of a group create objects of the same type.
+	_, processors := ctr.Lookup(members[0].Constructor.Type())
+	coerr.Collect(i.inspectProcessors(ctr, processors, bg))
+	return coerr.Error()
+}
+
+// inspectProcessors inspects that given processors of the same type have consistent
+// order constraints and their dependencies can be successfully satisfied by the given container.
+func (i *Inspector) inspectProcessors(ctr *kinit.Container, processors []kinit.Processor, bg *background) error {
+	coerr := kerror.NewCollector()
+	if _, err := kinit.Sequence(processors); err != nil {
+		coerr.Collect(err)
+	}
 	for _, proc := range processors {
-		coerr.Collect(i.inspectTypes(ctr, proc.Parameters(), bg))
+		coerr.Collect(i.inspectTypes(ctr, proc, proc.Parameters(), bg))
 	}
 	return coerr.Error()
 }
 
-// inspectTypes inspects given types together.
-func (i *Inspector) inspectTypes(ctr *kinit.Container, types []reflect.Type, bg *background) error {
+// inspectTypes inspects given types of dependencies of the given owner together.
+func (i *Inspector) inspectTypes(ctr *kinit.Container, owner interface{}, types []reflect.Type, bg *background) error {
 	coerr := kerror.NewCollector()
 	for _, t := range types {
-		coerr.Collect(i.inspectType(ctr, t, bg))
+		coerr.Collect(i.inspectType(ctr, owner, t, bg))
 	}
 	return coerr.Error()
 }
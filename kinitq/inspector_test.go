@@ -1,6 +1,7 @@
 package kinitq
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -37,6 +38,18 @@ func TestInspector__OKWhenIgnore(t *testing.T) {
 	}
 }
 
+func TestInspector__OKWhenContext(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(context.Context) int16 { return 0 }))
+	inspector := NewInspector()
+	inspector.MustRequire(reflect.TypeOf(int16(0)))
+	if err := inspector.Inspect(ctr, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
 func TestInspector__OKWhenInspectOnlyRequired(t *testing.T) {
 	ctr := kinit.NewContainer()
 	ctr.MustProvide(newTestConstructor(func(string) int16 { return 0 })) // unsatisfied dependency: string
@@ -98,6 +111,75 @@ func TestInspector__CyclicConstructorDependency(t *testing.T) {
 	}
 }
 
+func TestInspector__LazyCyclicDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(func() (int64, error)) int16 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(int16) int32 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(int32) int64 { return 0 }))
+	inspector := NewInspector()
+	inspector.MustRequire(reflect.TypeOf(int16(0)))
+	if err := inspector.Inspect(ctr, &Options{ReportUnused: true}); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__UnsatisfiedLazyDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(func() (int32, error)) int64 { return 0 }))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+	e, ok := err.(*UnsatisfiedError)
+	if !ok || e.Dependent != reflect.TypeOf(int64(0)) || e.Missing != reflect.TypeOf(int32(0)) {
+		t.Fail()
+		return
+	}
+}
+
+type testOptionalInt32 = struct {
+	Value   int32
+	Present bool
+}
+
+func TestInspector__OptionalDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(testOptionalInt32) int64 { return 0 }))
+	if err := NewInspector().Inspect(ctr, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__UnsatisfiedOptionalDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(testOptionalInt32) int64 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(string) int32 { return 0 }))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__CyclicOptionalDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func(testOptionalInt32) int64 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(int64) int32 { return 0 }))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
 func TestInspector__CyclicProcessorDependency(t *testing.T) {
 	ctr := kinit.NewContainer()
 	ctr.MustProvide(newTestConstructor(func(int64) int16 { return 0 }))
@@ -168,6 +250,41 @@ func TestInspector__UnsatisfiedProcessorDependency(t *testing.T) {
 	}
 }
 
+func TestInspector__NamedDependencies(t *testing.T) {
+	ctr := kinit.NewContainer()
+	primary := newTestConstructor(func() int32 { return 0 })
+	primary.t = kinit.Named(primary.t, "primary")
+	ctr.MustProvide(primary)
+	replica := newTestConstructor(func() int32 { return 0 })
+	replica.t = kinit.Named(replica.t, "replica")
+	ctr.MustProvide(replica)
+	dependent := newTestConstructor(func(int32, int32) int64 { return 0 })
+	dependent.in[0] = kinit.Named(dependent.in[0], "primary")
+	dependent.in[1] = kinit.Named(dependent.in[1], "replica")
+	ctr.MustProvide(dependent)
+	if err := NewInspector().Inspect(ctr, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__UnsatisfiedNamedDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	primary := newTestConstructor(func() int32 { return 0 })
+	primary.t = kinit.Named(primary.t, "primary")
+	ctr.MustProvide(primary)
+	dependent := newTestConstructor(func(int32) int64 { return 0 })
+	dependent.in[0] = kinit.Named(dependent.in[0], "replica")
+	ctr.MustProvide(dependent)
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
 func TestInspector__IrrelevantProcessors(t *testing.T) {
 	ctr := kinit.NewContainer()
 	ctr.MustAttach(newTestProcessor(func(int64) {}))
@@ -216,6 +333,36 @@ func TestInspector_Ignore__NilType(t *testing.T) {
 	}
 }
 
+func TestInspector_Consider(t *testing.T) {
+	ctr := kinit.NewContainer()
+	inspector := NewInspector()
+	inspector.MustConsider(newTestFunctor(func(int64) {}))
+	err := inspector.Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector_Consider__Nil(t *testing.T) {
+	err := NewInspector().Consider(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilInspector_Consider(t *testing.T) {
+	err := (*Inspector)(nil).Consider(newTestFunctor(func() {}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
 func TestNilInspector_Require(t *testing.T) {
 	err := (*Inspector)(nil).Require(reflect.TypeOf(0))
 	t.Logf("%+v", err)
@@ -241,3 +388,189 @@ func TestNilInspector_Inspect(t *testing.T) {
 		return
 	}
 }
+
+func TestInspector__Group(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func() int32 { return 0 }))
+	ctr.MustContribute(newTestConstructor(func(int32) int64 { return 0 }))
+	ctr.MustContribute(newTestConstructor(func() int64 { return 0 }))
+	ctr.MustAttach(newTestProcessor(func(int64, int32) {}))
+	ctr.MustProvide(newTestConstructor(func([]int64) uint64 { return 0 }))
+	inspector := NewInspector()
+	inspector.MustRequire(reflect.TypeOf(uint64(0)))
+	if err := inspector.Inspect(ctr, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__EmptyGroup(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustDeclareGroup(kinit.KeyedGroup(reflect.TypeOf(int64(0))))
+	ctr.MustProvide(newTestConstructor(func(map[string]int64) uint64 { return 0 }))
+	inspector := NewInspector()
+	inspector.MustRequire(reflect.TypeOf(uint64(0)))
+	if err := inspector.Inspect(ctr, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__UnsatisfiedGroupMemberDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustContribute(newTestConstructor(func() int64 { return 0 }))
+	ctr.MustContribute(newTestConstructor(func(int32) int64 { return 0 }))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__CyclicGroupMemberDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustContribute(newTestConstructor(func(int32) int64 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func([]int64) int32 { return 0 }))
+	inspector := NewInspector()
+	inspector.MustRequire(reflect.TypeOf(int32(0)))
+	err := inspector.Inspect(ctr, &Options{
+		InspectOnlyRequired: true,
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__UnsatisfiedKeyedGroupMemberDependency(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvideInto("one", newTestConstructor(func() int64 { return 0 }))
+	ctr.MustProvideInto("two", newTestConstructor(func(int32) int64 { return 0 }))
+	ctr.MustAttach(newTestProcessor(func(int64) {}))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__SequencedProcessors(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func() int64 { return 0 }))
+	ctr.MustAttach(newTestSequencedProcessor("loops", []string{"metrics"}, func(int64) {}))
+	ctr.MustAttach(newTestSequencedProcessor("metrics", []string{"config"}, func(int64) {}))
+	ctr.MustAttach(newTestSequencedProcessor("config", nil, func(int64) {}))
+	if err := NewInspector().Inspect(ctr, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__ContradictoryProcessors(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func() int64 { return 0 }))
+	ctr.MustAttach(newTestSequencedProcessor("metrics", []string{"loops"}, func(int64) {}))
+	ctr.MustAttach(newTestSequencedProcessor("loops", []string{"metrics"}, func(int64) {}))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__ContradictoryGroupMemberProcessors(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustContribute(newTestConstructor(func() int64 { return 0 }))
+	ctr.MustContribute(newTestConstructor(func() int64 { return 0 }))
+	ctr.MustAttach(newTestSequencedProcessor("metrics", []string{"loops"}, func(int64) {}))
+	ctr.MustAttach(newTestSequencedProcessor("loops", []string{"metrics"}, func(int64) {}))
+	err := NewInspector().Inspect(ctr, nil)
+	t.Logf("%+v", err)
+	if errs, ok := err.(kerror.MultiError); ok || kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Logf("%d", len(errs))
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__ReportUnused(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func() int16 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(int16) int32 { return 0 }))
+	ctr.MustAttach(newTestProcessor(func(int32, uint8) {}))
+	ctr.MustProvide(newTestConstructor(func() uint8 { return 0 }))
+	ctr.MustContribute(newTestConstructor(func() uint16 { return 0 }))
+	ctr.MustAttach(newTestProcessor(func(uint16) {}))
+	ctr.MustProvide(newTestConstructor(func() int64 { return 0 }))     // unused
+	ctr.MustAttach(newTestProcessor(func(int64) {}))                   // unused
+	ctr.MustContribute(newTestConstructor(func() uint32 { return 0 })) // unused
+	ctr.MustAttach(newTestProcessor(func(uint32) {}))                  // unused
+	ctr.MustProvide(newTestConstructor(func() uint64 { return 0 }))    // allowed
+	inspector := NewInspector()
+	inspector.MustConsider(newTestFunctor(func(int32, []uint16) {}))
+	inspector.MustIgnore(reflect.TypeOf(uint64(0)))
+	err := inspector.Inspect(ctr, &Options{
+		ReportUnused: true,
+	})
+	t.Logf("%+v", err)
+	errs, ok := err.(kerror.MultiError)
+	if !ok {
+		t.Fail()
+		return
+	}
+	unused := make(map[string]bool)
+	for _, err := range errs {
+		e, ok := err.(*UnusedError)
+		if !ok {
+			t.Fail()
+			return
+		}
+		unused[string(e.Kind)+" "+e.Type.String()] = true
+	}
+	expected := map[string]bool{
+		"constructor int64": true,
+		"processor int64":   true,
+		"group []uint32":    true,
+		"processor uint32":  true,
+	}
+	if !reflect.DeepEqual(unused, expected) {
+		t.Logf("%v", unused)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__ReportUnusedThroughIgnored(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func() int32 { return 0 }))
+	ctr.MustProvide(newTestConstructor(func(int32) int64 { return 0 }))
+	inspector := NewInspector()
+	inspector.MustConsider(newTestFunctor(func(int64) {}))
+	inspector.MustIgnore(reflect.TypeOf(int64(0)))
+	if err := inspector.Inspect(ctr, &Options{ReportUnused: true}); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspector__ReportUnusedWithoutRoots(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(newTestConstructor(func() int16 { return 0 }))
+	err := NewInspector().Inspect(ctr, &Options{
+		InspectOnlyRequired: true,
+		ReportUnused:        true,
+	})
+	t.Logf("%+v", err)
+	if _, ok := err.(*UnusedError); !ok {
+		t.Fail()
+		return
+	}
+}
@@ -31,3 +31,21 @@ func (p *testProcessor) Parameters() []reflect.Type {
 func (p *testProcessor) Process(obj reflect.Value, a ...reflect.Value) error {
 	return nil
 }
+
+type testSequencedProcessor struct {
+	*testProcessor
+	label string
+	after []string
+}
+
+func newTestSequencedProcessor(label string, after []string, x interface{}) *testSequencedProcessor {
+	return &testSequencedProcessor{newTestProcessor(x), label, after}
+}
+
+func (p *testSequencedProcessor) Label() string {
+	return p.label
+}
+
+func (p *testSequencedProcessor) After() []string {
+	return p.after
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
 )
 
 // Binder represents a pseudo-constructor that casts an object to an interface.
@@ -13,6 +14,8 @@ type Binder struct {
 	t reflect.Type
 	// inType specifies the type of the input object to cast.
 	inType reflect.Type
+	// desc specifies the description of this binder.
+	desc kinit.Description
 }
 
 // NewBinder returns a new binder.
@@ -29,20 +32,38 @@ func NewBinder(i, x interface{}) (*Binder, error) {
 	if pt.Kind() != reflect.Ptr {
 		return nil, kerror.Newf(kerror.EViolation, "interface pointer expected, %s given", pt)
 	}
-	it := pt.Elem()
-	if it.Kind() != reflect.Interface {
-		return nil, kerror.Newf(kerror.EViolation, "interface pointer expected, %s given", pt)
+	return NewTypeBinder(pt.Elem(), reflect.TypeOf(x))
+}
+
+// NewTypeBinder returns a new binder that casts objects of the type t to the interface i.
+//
+// Unlike the NewBinder it accepts types, thus the type t may be an interface type as well.
+func NewTypeBinder(i, t reflect.Type) (*Binder, error) {
+	if i == nil || i.Kind() != reflect.Interface {
+		return nil, kerror.Newf(kerror.EViolation, "interface type expected, %v given", i)
 	}
-	ot := reflect.TypeOf(x)
-	if !ot.Implements(it) {
-		return nil, kerror.Newf(kerror.EViolation, "%s doesn't implement %s", ot, pt)
+	if t == nil {
+		return nil, kerror.New(kerror.EViolation, "type expected, nil given")
+	}
+	if !t.Implements(i) {
+		return nil, kerror.Newf(kerror.EViolation, "%s doesn't implement %s", t, i)
 	}
 	return &Binder{
-		t:      it,
-		inType: ot,
+		t:      i,
+		inType: t,
+		desc:   describeType(t),
 	}, nil
 }
 
+// MustNewTypeBinder is a variant of the NewTypeBinder that panics on error.
+func MustNewTypeBinder(i, t reflect.Type) *Binder {
+	b, err := NewTypeBinder(i, t)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
 // MustNewBinder is a variant of the NewBinder that panics on error.
 func MustNewBinder(i, x interface{}) *Binder {
 	b, err := NewBinder(i, x)
@@ -84,3 +105,11 @@ func (b *Binder) Create(a ...reflect.Value) (reflect.Value, kdone.Destructor, er
 	}
 	return a[0].Convert(b.t), kdone.Noop, nil
 }
+
+// Describe implements the kinit.Describer interface.
+func (b *Binder) Describe() kinit.Description {
+	if b == nil {
+		return kinit.Description{}
+	}
+	return b.desc
+}
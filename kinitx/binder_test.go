@@ -1,12 +1,14 @@
 package kinitx
 
 import (
+	"context"
 	"io"
 	"reflect"
 	"testing"
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
 )
 
 func TestBinder(t *testing.T) {
@@ -52,6 +54,34 @@ func TestBinder(t *testing.T) {
 	}
 }
 
+type testBinderService struct {
+	starts int
+	stops  int
+}
+
+func (s *testBinderService) Start(ctx context.Context) error {
+	s.starts++
+	return nil
+}
+
+func (s *testBinderService) Stop(ctx context.Context) error {
+	s.stops++
+	return nil
+}
+
+func TestBinder__Lifecycle(t *testing.T) {
+	service := &testBinderService{}
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewConstructor(func() *testBinderService { return service }))
+	ctr.MustProvide(MustNewBinder((*kinit.Starter)(nil), (*testBinderService)(nil)))
+	ctr.MustRun(MustNewFunctor(func(*testBinderService, kinit.Starter) {}))
+	if service.starts != 1 || service.stops != 1 {
+		t.Logf("starts: %d, stops: %d", service.starts, service.stops)
+		t.Fail()
+		return
+	}
+}
+
 func TestNewBinder__NilInterfacePointer(t *testing.T) {
 	_, err := NewBinder(nil, 0)
 	t.Logf("%+v", err)
@@ -97,6 +127,24 @@ func TestNewBinder__IncompatibleObject(t *testing.T) {
 	}
 }
 
+func TestNewTypeBinder__NilInterfaceType(t *testing.T) {
+	_, err := NewTypeBinder(nil, reflect.TypeOf(""))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewTypeBinder__NilType(t *testing.T) {
+	_, err := NewTypeBinder(reflect.TypeOf((*io.Closer)(nil)).Elem(), nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
 func TestBinder_Create__WrongArgumentNumber(t *testing.T) {
 	ctor := MustNewBinder((*io.Closer)(nil), (kdone.CloserFunc)(nil))
 	t.Logf("%+v %+v", ctor.Type(), ctor.Parameters())
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
 )
 
 // Constructor represents a constructor based on a function.
@@ -13,8 +14,11 @@ type Constructor struct {
 	t reflect.Type
 	// function specifies the reflection to a function value.
 	function reflect.Value
-	// inTypes specifies types of function input parameters.
+	// inTypes specifies types of dependencies function input parameters are expanded to.
 	inTypes []reflect.Type
+	// inObjects specifies initializers of parameter objects associated with indexes
+	// of function input parameters (nil if there are no parameter objects).
+	inObjects []*Initializer
 	// objectOutIndex specifies the index of a function output parameter that contains a created object.
 	objectOutIndex int
 	// destructorOutIndex specifies the index of a function output parameter that contains a destructor.
@@ -23,6 +27,8 @@ type Constructor struct {
 	// errorOutIndex specifies the index of a function output parameter that contains an error.
 	// The value -1 means that a function doesn't return an error.
 	errorOutIndex int
+	// desc specifies the description of this constructor.
+	desc kinit.Description
 }
 
 // NewConstructor returns a new constructor.
@@ -36,6 +42,7 @@ type Constructor struct {
 //
 //     func(...) (T, kdone.Destructor, error).
 //
+// Parameter objects (see the In) are expanded into dependencies of their fields.
 func NewConstructor(x interface{}) (*Constructor, error) {
 	if x == nil {
 		return nil, kerror.New(kerror.EViolation, "function expected, nil given")
@@ -50,14 +57,19 @@ func NewConstructor(x interface{}) (*Constructor, error) {
 	}
 	c := &Constructor{
 		function: fv,
+		desc:     describeFunction(fv),
 	}
 	numIn := ft.NumIn()
 	if ft.IsVariadic() {
 		numIn--
 	}
-	c.inTypes = make([]reflect.Type, numIn)
+	params := make([]reflect.Type, numIn)
 	for i := 0; i < numIn; i++ {
-		c.inTypes[i] = ft.In(i)
+		params[i] = ft.In(i)
+	}
+	var err error
+	if c.inTypes, c.inObjects, err = expandParameters(params); err != nil {
+		return nil, err
 	}
 	switch ft.NumOut() {
 	default:
@@ -125,13 +137,17 @@ func (c *Constructor) Create(a ...reflect.Value) (reflect.Value, kdone.Destructo
 			c.t, len(c.inTypes), len(a))
 	}
 	for i, v := range a {
-		if v.Type() != c.inTypes[i] {
+		if v.Type() != kinit.Actual(c.inTypes[i]) {
 			return reflect.Value{}, nil, kerror.Newf(kerror.EViolation,
 				"%s constructor expects argument %d to be of %s type, %s given",
-				c.t, i+1, c.inTypes[i], v.Type())
+				c.t, i+1, kinit.Actual(c.inTypes[i]), v.Type())
 		}
 	}
-	out := c.function.Call(a)
+	in, err := collapseArguments(c.inObjects, a)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	out := c.function.Call(in)
 	obj := out[c.objectOutIndex]
 	var dtor kdone.Destructor = kdone.Noop
 	if c.destructorOutIndex >= 0 {
@@ -139,7 +155,6 @@ func (c *Constructor) Create(a ...reflect.Value) (reflect.Value, kdone.Destructo
 			dtor = v.(kdone.Destructor)
 		}
 	}
-	var err error
 	if c.errorOutIndex >= 0 {
 		if v := out[c.errorOutIndex].Interface(); v != nil {
 			err = v.(error)
@@ -147,3 +162,11 @@ func (c *Constructor) Create(a ...reflect.Value) (reflect.Value, kdone.Destructo
 	}
 	return obj, dtor, err
 }
+
+// Describe implements the kinit.Describer interface.
+func (c *Constructor) Describe() kinit.Description {
+	if c == nil {
+		return kinit.Description{}
+	}
+	return c.desc
+}
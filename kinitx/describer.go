@@ -0,0 +1,54 @@
+package kinitx
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/go-kata/kinit"
+)
+
+// pkgPath specifies the import path of this package.
+var pkgPath = reflect.TypeOf(Constructor{}).PkgPath()
+
+// describeFunction returns the description of an entity based on the given function.
+func describeFunction(fv reflect.Value) kinit.Description {
+	d := kinit.Description{
+		Caller: caller(),
+	}
+	if f := runtime.FuncForPC(fv.Pointer()); f != nil {
+		d.Name = f.Name()
+		file, line := f.FileLine(f.Entry())
+		d.Location = file + ":" + strconv.Itoa(line)
+	}
+	return d
+}
+
+// describeType returns the description of an entity based on the given type.
+func describeType(t reflect.Type) kinit.Description {
+	return kinit.Description{
+		Name:   t.String(),
+		Caller: caller(),
+	}
+}
+
+// caller returns the source location of the nearest call from outside of this package
+// (and its subpackages) in the file:line format.
+//
+// Calls from test files of this package are considered as outside ones.
+func caller() string {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		internal := strings.HasPrefix(frame.Function, pkgPath+".") || strings.HasPrefix(frame.Function, pkgPath+"/")
+		if !internal || strings.HasSuffix(frame.File, "_test.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
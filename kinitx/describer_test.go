@@ -0,0 +1,61 @@
+package kinitx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testDescribedObject struct{}
+
+func newTestDescribedObject() *testDescribedObject {
+	return &testDescribedObject{}
+}
+
+func TestConstructor_Describe(t *testing.T) {
+	d := MustNewConstructor(newTestDescribedObject).Describe()
+	t.Logf("%s", d)
+	if !strings.HasSuffix(d.Name, ".newTestDescribedObject") ||
+		!strings.Contains(d.Location, "describer_test.go:") || !strings.Contains(d.Caller, "describer_test.go:") {
+		t.Fail()
+		return
+	}
+}
+
+func TestInitializer_Describe(t *testing.T) {
+	d := MustNewInitializer((*testDescribedObject)(nil)).Describe()
+	t.Logf("%s", d)
+	if d.Name != "*kinitx.testDescribedObject" || d.Location != "" || !strings.Contains(d.Caller, "describer_test.go:") {
+		t.Fail()
+		return
+	}
+}
+
+func TestNamedConstructor_Describe(t *testing.T) {
+	d := MustNewNamedConstructor("test", newTestDescribedObject).Describe()
+	t.Logf("%s", d)
+	if !strings.HasSuffix(d.Name, ".newTestDescribedObject") {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Provide__Described(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewConstructor(newTestDescribedObject))
+	err := ctr.Provide(MustNewInitializer((*testDescribedObject)(nil)))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous || !strings.Contains(err.Error(), ".newTestDescribedObject at ") {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilConstructor_Describe(t *testing.T) {
+	if (*Constructor)(nil).Describe() != (kinit.Description{}) {
+		t.Fail()
+		return
+	}
+}
@@ -11,8 +11,11 @@ import (
 type Functor struct {
 	// function specifies the reflection to a function value.
 	function reflect.Value
-	// inTypes specifies types of function input parameters.
+	// inTypes specifies types of dependencies function input parameters are expanded to.
 	inTypes []reflect.Type
+	// inObjects specifies initializers of parameter objects associated with indexes
+	// of function input parameters (nil if there are no parameter objects).
+	inObjects []*Initializer
 	// furtherOutIndex specifies the index of a function output parameter that contains further functor(s).
 	// The value -1 means that a function doesn't return subsequent functor(s).
 	furtherOutIndex int
@@ -21,6 +24,8 @@ type Functor struct {
 	// errorOutIndex specifies the index of a function output parameter that contains an error.
 	// The value -1 means that a function doesn't return an error.
 	errorOutIndex int
+	// desc specifies the description of this functor.
+	desc kinit.Description
 }
 
 // NewFunctor returns a new functor.
@@ -35,6 +40,7 @@ type Functor struct {
 //
 //     func(...) ([]kinit.Functor, error)
 //
+// Parameter objects (see the In) are expanded into dependencies of their fields.
 func NewFunctor(x interface{}) (*Functor, error) {
 	if x == nil {
 		return nil, kerror.New(kerror.EViolation, "function expected, nil given")
@@ -49,14 +55,19 @@ func NewFunctor(x interface{}) (*Functor, error) {
 	}
 	f := &Functor{
 		function: fv,
+		desc:     describeFunction(fv),
 	}
 	numIn := ft.NumIn()
 	if ft.IsVariadic() {
 		numIn--
 	}
-	f.inTypes = make([]reflect.Type, numIn)
+	params := make([]reflect.Type, numIn)
 	for i := 0; i < numIn; i++ {
-		f.inTypes[i] = ft.In(i)
+		params[i] = ft.In(i)
+	}
+	var err error
+	if f.inTypes, f.inObjects, err = expandParameters(params); err != nil {
+		return nil, err
 	}
 	switch ft.NumOut() {
 	default:
@@ -114,13 +125,17 @@ func (f *Functor) Call(a ...reflect.Value) ([]kinit.Functor, error) {
 			"functor expects %d argument(s), %d given", len(f.inTypes), len(a))
 	}
 	for i, v := range a {
-		if v.Type() != f.inTypes[i] {
+		if v.Type() != kinit.Actual(f.inTypes[i]) {
 			return nil, kerror.Newf(kerror.EViolation,
 				"functor expects argument %d to be of %s type, %s given",
-				i+1, f.inTypes[i], v.Type())
+				i+1, kinit.Actual(f.inTypes[i]), v.Type())
 		}
 	}
-	out := f.function.Call(a)
+	in, err := collapseArguments(f.inObjects, a)
+	if err != nil {
+		return nil, err
+	}
+	out := f.function.Call(in)
 	var further []kinit.Functor
 	if f.furtherOutIndex >= 0 {
 		if v := out[f.furtherOutIndex].Interface(); v != nil {
@@ -131,7 +146,6 @@ func (f *Functor) Call(a ...reflect.Value) ([]kinit.Functor, error) {
 			}
 		}
 	}
-	var err error
 	if f.errorOutIndex >= 0 {
 		if v := out[f.errorOutIndex].Interface(); v != nil {
 			err = v.(error)
@@ -139,3 +153,11 @@ func (f *Functor) Call(a ...reflect.Value) ([]kinit.Functor, error) {
 	}
 	return further, err
 }
+
+// Describe implements the kinit.Describer interface.
+func (f *Functor) Describe() kinit.Description {
+	if f == nil {
+		return kinit.Description{}
+	}
+	return f.desc
+}
@@ -1,6 +1,7 @@
 package kinitx
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -23,6 +24,23 @@ func TestFunctor__FunctionReturningNothing(t *testing.T) {
 	}
 }
 
+func TestFunctor__FunctionWithContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, 1)
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewConstructor(func(ctx context.Context) int {
+		return ctx.Value(key{}).(int)
+	}))
+	var c int
+	ctr.MustRunContext(ctx, MustNewFunctor(func(ctx context.Context, v int) {
+		c = ctx.Value(key{}).(int) + v
+	}))
+	if c != 2 {
+		t.Fail()
+		return
+	}
+}
+
 func TestFunctor__FunctionReturningError(t *testing.T) {
 	var c int
 	fun := MustNewFunctor(func(v *int) error {
@@ -0,0 +1,293 @@
+// Package generic provides the type-safe API of the KInit expansion set based on generics.
+//
+// Functions of this package produce the same constructors and processors as the kinitx does,
+// but signatures of functions they are based on are checked at compile time. Functions with more
+// dependencies than the arity of provided variants may accept a parameter object (see the kinitx.In).
+//
+// Constructors are provided by functions of three families matching signatures accepted by the kinitx:
+// the Provide for func(...) (T, error), the ProvidePlain for func(...) T and the ProvideDestructible
+// for func(...) (T, kdone.Destructor, error). Processors are attached by the Attach for func(T, ...) error
+// and by the AttachPlain for func(T, ...).
+package generic
+
+import (
+	"reflect"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kinit"
+	"github.com/go-kata/kinit/kinitx"
+)
+
+// Provide calls the Provide method of the given container by passing a constructor based on the given function.
+//
+// If the function creates result objects (see the kinitx.Out) their fields will be provided too.
+func Provide[T any](ctr *kinit.Container, f func() (T, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvide is a variant of the Provide that panics on error.
+func MustProvide[T any](ctr *kinit.Container, f func() (T, error)) {
+	if err := Provide(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// Provide1 is a variant of the Provide for functions with one dependency.
+func Provide1[T, A1 any](ctr *kinit.Container, f func(A1) (T, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvide1 is a variant of the Provide1 that panics on error.
+func MustProvide1[T, A1 any](ctr *kinit.Container, f func(A1) (T, error)) {
+	if err := Provide1(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// Provide2 is a variant of the Provide for functions with two dependencies.
+func Provide2[T, A1, A2 any](ctr *kinit.Container, f func(A1, A2) (T, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvide2 is a variant of the Provide2 that panics on error.
+func MustProvide2[T, A1, A2 any](ctr *kinit.Container, f func(A1, A2) (T, error)) {
+	if err := Provide2(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// Provide3 is a variant of the Provide for functions with three dependencies.
+func Provide3[T, A1, A2, A3 any](ctr *kinit.Container, f func(A1, A2, A3) (T, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvide3 is a variant of the Provide3 that panics on error.
+func MustProvide3[T, A1, A2, A3 any](ctr *kinit.Container, f func(A1, A2, A3) (T, error)) {
+	if err := Provide3(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvidePlain is a variant of the Provide for functions that don't return an error.
+func ProvidePlain[T any](ctr *kinit.Container, f func() T) error {
+	return provide(ctr, f)
+}
+
+// MustProvidePlain is a variant of the ProvidePlain that panics on error.
+func MustProvidePlain[T any](ctr *kinit.Container, f func() T) {
+	if err := ProvidePlain(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvidePlain1 is a variant of the ProvidePlain for functions with one dependency.
+func ProvidePlain1[T, A1 any](ctr *kinit.Container, f func(A1) T) error {
+	return provide(ctr, f)
+}
+
+// MustProvidePlain1 is a variant of the ProvidePlain1 that panics on error.
+func MustProvidePlain1[T, A1 any](ctr *kinit.Container, f func(A1) T) {
+	if err := ProvidePlain1(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvidePlain2 is a variant of the ProvidePlain for functions with two dependencies.
+func ProvidePlain2[T, A1, A2 any](ctr *kinit.Container, f func(A1, A2) T) error {
+	return provide(ctr, f)
+}
+
+// MustProvidePlain2 is a variant of the ProvidePlain2 that panics on error.
+func MustProvidePlain2[T, A1, A2 any](ctr *kinit.Container, f func(A1, A2) T) {
+	if err := ProvidePlain2(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvidePlain3 is a variant of the ProvidePlain for functions with three dependencies.
+func ProvidePlain3[T, A1, A2, A3 any](ctr *kinit.Container, f func(A1, A2, A3) T) error {
+	return provide(ctr, f)
+}
+
+// MustProvidePlain3 is a variant of the ProvidePlain3 that panics on error.
+func MustProvidePlain3[T, A1, A2, A3 any](ctr *kinit.Container, f func(A1, A2, A3) T) {
+	if err := ProvidePlain3(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideDestructible is a variant of the Provide for functions that return a destructor along with an object.
+func ProvideDestructible[T any](ctr *kinit.Container, f func() (T, kdone.Destructor, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvideDestructible is a variant of the ProvideDestructible that panics on error.
+func MustProvideDestructible[T any](ctr *kinit.Container, f func() (T, kdone.Destructor, error)) {
+	if err := ProvideDestructible(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideDestructible1 is a variant of the ProvideDestructible for functions with one dependency.
+func ProvideDestructible1[T, A1 any](ctr *kinit.Container, f func(A1) (T, kdone.Destructor, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvideDestructible1 is a variant of the ProvideDestructible1 that panics on error.
+func MustProvideDestructible1[T, A1 any](ctr *kinit.Container, f func(A1) (T, kdone.Destructor, error)) {
+	if err := ProvideDestructible1(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideDestructible2 is a variant of the ProvideDestructible for functions with two dependencies.
+func ProvideDestructible2[T, A1, A2 any](ctr *kinit.Container, f func(A1, A2) (T, kdone.Destructor, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvideDestructible2 is a variant of the ProvideDestructible2 that panics on error.
+func MustProvideDestructible2[T, A1, A2 any](ctr *kinit.Container, f func(A1, A2) (T, kdone.Destructor, error)) {
+	if err := ProvideDestructible2(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideDestructible3 is a variant of the ProvideDestructible for functions with three dependencies.
+func ProvideDestructible3[T, A1, A2, A3 any](ctr *kinit.Container, f func(A1, A2, A3) (T, kdone.Destructor, error)) error {
+	return provide(ctr, f)
+}
+
+// MustProvideDestructible3 is a variant of the ProvideDestructible3 that panics on error.
+func MustProvideDestructible3[T, A1, A2, A3 any](ctr *kinit.Container, f func(A1, A2, A3) (T, kdone.Destructor, error)) {
+	if err := ProvideDestructible3(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// provide calls the kinitx.ProvideExpanded by passing the given container
+// and a constructor based on the given function.
+func provide(ctr *kinit.Container, f interface{}) error {
+	ctor, err := kinitx.NewConstructor(f)
+	if err != nil {
+		return err
+	}
+	return kinitx.ProvideExpanded(ctr, ctor)
+}
+
+// Bind calls the Provide method of the given container by passing a binder
+// that casts objects of the type T to the interface I.
+//
+// The type T may be an interface type as well.
+func Bind[I, T any](ctr *kinit.Container) error {
+	ctor, err := kinitx.NewTypeBinder(reflect.TypeOf((*I)(nil)).Elem(), reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+	return ctr.Provide(ctor)
+}
+
+// MustBind is a variant of the Bind that panics on error.
+func MustBind[I, T any](ctr *kinit.Container) {
+	if err := Bind[I, T](ctr); err != nil {
+		panic(err)
+	}
+}
+
+// Attach calls the Attach method of the given container by passing a processor based on the given function.
+func Attach[T any](ctr *kinit.Container, f func(T) error) error {
+	return attach(ctr, f)
+}
+
+// MustAttach is a variant of the Attach that panics on error.
+func MustAttach[T any](ctr *kinit.Container, f func(T) error) {
+	if err := Attach(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// Attach1 is a variant of the Attach for functions with one dependency.
+func Attach1[T, A1 any](ctr *kinit.Container, f func(T, A1) error) error {
+	return attach(ctr, f)
+}
+
+// MustAttach1 is a variant of the Attach1 that panics on error.
+func MustAttach1[T, A1 any](ctr *kinit.Container, f func(T, A1) error) {
+	if err := Attach1(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// Attach2 is a variant of the Attach for functions with two dependencies.
+func Attach2[T, A1, A2 any](ctr *kinit.Container, f func(T, A1, A2) error) error {
+	return attach(ctr, f)
+}
+
+// MustAttach2 is a variant of the Attach2 that panics on error.
+func MustAttach2[T, A1, A2 any](ctr *kinit.Container, f func(T, A1, A2) error) {
+	if err := Attach2(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// AttachPlain is a variant of the Attach for functions that don't return an error.
+func AttachPlain[T any](ctr *kinit.Container, f func(T)) error {
+	return attach(ctr, f)
+}
+
+// MustAttachPlain is a variant of the AttachPlain that panics on error.
+func MustAttachPlain[T any](ctr *kinit.Container, f func(T)) {
+	if err := AttachPlain(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// AttachPlain1 is a variant of the AttachPlain for functions with one dependency.
+func AttachPlain1[T, A1 any](ctr *kinit.Container, f func(T, A1)) error {
+	return attach(ctr, f)
+}
+
+// MustAttachPlain1 is a variant of the AttachPlain1 that panics on error.
+func MustAttachPlain1[T, A1 any](ctr *kinit.Container, f func(T, A1)) {
+	if err := AttachPlain1(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// AttachPlain2 is a variant of the AttachPlain for functions with two dependencies.
+func AttachPlain2[T, A1, A2 any](ctr *kinit.Container, f func(T, A1, A2)) error {
+	return attach(ctr, f)
+}
+
+// MustAttachPlain2 is a variant of the AttachPlain2 that panics on error.
+func MustAttachPlain2[T, A1, A2 any](ctr *kinit.Container, f func(T, A1, A2)) {
+	if err := AttachPlain2(ctr, f); err != nil {
+		panic(err)
+	}
+}
+
+// attach calls the Attach method of the given container by passing a processor based on the given function.
+func attach(ctr *kinit.Container, f interface{}) error {
+	proc, err := kinitx.NewProcessor(f)
+	if err != nil {
+		return err
+	}
+	return ctr.Attach(proc)
+}
+
+// Resolve calls the Resolve method of the given runtime by passing the type T.
+//
+// The type T may be an interface type as well.
+func Resolve[T any](rt *kinit.Runtime) (T, error) {
+	var obj T
+	err := kinitx.Resolve(rt, &obj)
+	return obj, err
+}
+
+// MustResolve is a variant of the Resolve that panics on error.
+func MustResolve[T any](rt *kinit.Runtime) T {
+	obj, err := Resolve[T](rt)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
@@ -0,0 +1,163 @@
+package generic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+	"github.com/go-kata/kinit/kinitx"
+)
+
+type testConfig struct {
+	port int
+}
+
+type testServer struct {
+	config *testConfig
+	name   string
+}
+
+func (s *testServer) String() string {
+	return fmt.Sprintf("%s:%d", s.name, s.config.port)
+}
+
+func TestGeneric(t *testing.T) {
+	ctr := kinit.NewContainer()
+	MustProvide(ctr, func() (*testConfig, error) {
+		return &testConfig{port: 8080}, nil
+	})
+	MustProvide1(ctr, func(config *testConfig) (*testServer, error) {
+		return &testServer{config: config}, nil
+	})
+	MustAttach1(ctr, func(server *testServer, config *testConfig) error {
+		server.name = "localhost"
+		return nil
+	})
+	MustBind[fmt.Stringer, *testServer](ctr)
+	var s string
+	ctr.MustRun(kinitx.MustNewFunctor(func(rt *kinit.Runtime) error {
+		stringer, err := Resolve[fmt.Stringer](rt)
+		if err != nil {
+			return err
+		}
+		s = stringer.String()
+		return nil
+	}))
+	if s != "localhost:8080" {
+		t.Logf("%q", s)
+		t.Fail()
+		return
+	}
+}
+
+type testNamedStringer interface {
+	fmt.Stringer
+	Name() string
+}
+
+func (s *testServer) Name() string {
+	return s.name
+}
+
+func TestBind__Interface(t *testing.T) {
+	ctr := kinit.NewContainer()
+	MustProvide(ctr, func() (testNamedStringer, error) {
+		return &testServer{config: &testConfig{port: 8080}, name: "localhost"}, nil
+	})
+	MustBind[fmt.Stringer, testNamedStringer](ctr)
+	var s string
+	ctr.MustRun(kinitx.MustNewFunctor(func(rt *kinit.Runtime) error {
+		stringer, err := Resolve[fmt.Stringer](rt)
+		if err != nil {
+			return err
+		}
+		s = stringer.String()
+		return nil
+	}))
+	if s != "localhost:8080" {
+		t.Logf("%q", s)
+		t.Fail()
+		return
+	}
+}
+
+func TestBind__NotImplemented(t *testing.T) {
+	err := Bind[fmt.Stringer, *testConfig](kinit.NewContainer())
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestResolve__NotFound(t *testing.T) {
+	err := kinit.NewContainer().Run(kinitx.MustNewFunctor(func(rt *kinit.Runtime) error {
+		_, err := Resolve[*testConfig](rt)
+		return err
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
+func TestResolve__NilRuntime(t *testing.T) {
+	_, err := Resolve[*testConfig](nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+type testResult struct {
+	kinitx.Out
+	Config *testConfig
+	Server *testServer
+}
+
+func TestProvide__ResultObject(t *testing.T) {
+	ctr := kinit.NewContainer()
+	MustProvide(ctr, func() (testResult, error) {
+		config := &testConfig{port: 8080}
+		return testResult{Config: config, Server: &testServer{config: config, name: "localhost"}}, nil
+	})
+	var s string
+	ctr.MustRun(kinitx.MustNewFunctor(func(server *testServer) {
+		s = server.String()
+	}))
+	if s != "localhost:8080" {
+		t.Logf("%q", s)
+		t.Fail()
+		return
+	}
+}
+
+func TestProvidePlain(t *testing.T) {
+	var destroyed bool
+	ctr := kinit.NewContainer()
+	MustProvidePlain(ctr, func() *testConfig {
+		return &testConfig{port: 8080}
+	})
+	MustProvideDestructible1(ctr, func(config *testConfig) (*testServer, kdone.Destructor, error) {
+		return &testServer{config: config}, kdone.DestructorFunc(func() error {
+			destroyed = true
+			return nil
+		}), nil
+	})
+	MustAttachPlain(ctr, func(server *testServer) {
+		server.name = "localhost"
+	})
+	var s string
+	ctr.MustRun(kinitx.MustNewFunctor(func(server *testServer) {
+		s = server.String()
+	}))
+	if s != "localhost:8080" || !destroyed {
+		t.Logf("%q, %t", s, destroyed)
+		t.Fail()
+		return
+	}
+}
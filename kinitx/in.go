@@ -0,0 +1,89 @@
+package kinitx
+
+import "reflect"
+
+// In represents a marker of parameter objects.
+//
+// A struct embedding the In that is a parameter of a function constructors, processors and functors
+// are based on will be expanded into dependencies of its exported fields like the Initializer does
+// (including the support of field tags) and filled before the function call:
+//
+//     type ServerParams struct {
+//         kinitx.In
+//         Config  *Config
+//         Logger  *log.Logger `kinit:"optional"`
+//         Handlers []Handler  `kinit:"group=handlers"`
+//     }
+//
+//     func NewServer(params ServerParams) *Server { ... }
+//
+type In struct{}
+
+// inType specifies the reflection to the In type.
+var inType = reflect.TypeOf(In{})
+
+// isParameterObject returns boolean specifies whether the given type is a type of parameter objects.
+func isParameterObject(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i, n := 0, t.NumField(); i < n; i++ {
+		if sf := t.Field(i); sf.Anonymous && sf.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// expandParameters returns types of dependencies given function input parameters are expanded to
+// and initializers of parameter objects associated with indexes of parameters (nil for other parameters).
+func expandParameters(params []reflect.Type) ([]reflect.Type, []*Initializer, error) {
+	var types []reflect.Type
+	var objects []*Initializer
+	for i, t := range params {
+		if !isParameterObject(t) {
+			types = append(types, t)
+			continue
+		}
+		if objects == nil {
+			objects = make([]*Initializer, len(params))
+		}
+		object, err := NewInitializer(reflect.Zero(t).Interface())
+		if err != nil {
+			return nil, nil, err
+		}
+		objects[i] = object
+		types = append(types, object.Parameters()...)
+	}
+	if types == nil {
+		types = []reflect.Type{}
+	}
+	return types, objects, nil
+}
+
+// collapseArguments returns function arguments built from given dependencies
+// using given initializers of parameter objects (see the expandParameters).
+//
+// The number of dependencies must be already checked.
+func collapseArguments(objects []*Initializer, a []reflect.Value) ([]reflect.Value, error) {
+	if objects == nil {
+		return a, nil
+	}
+	in := make([]reflect.Value, len(objects))
+	k := 0
+	for i, object := range objects {
+		if object == nil {
+			in[i] = a[k]
+			k++
+			continue
+		}
+		n := len(object.assignableFieldTypes)
+		obj, _, err := object.Create(a[k : k+n]...)
+		if err != nil {
+			return nil, err
+		}
+		in[i] = obj
+		k += n
+	}
+	return in, nil
+}
@@ -0,0 +1,91 @@
+package kinitx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testInParams struct {
+	In
+	Value    int
+	Name     string  `kinit:"name=name"`
+	Ratio    float64 `kinit:"optional"`
+	Skipped  bool    `kinit:"-"`
+	Handlers []int8  `kinit:"group"`
+}
+
+func newTestInContainer() *kinit.Container {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewConstructor(func() int { return 1 }))
+	ctr.MustProvide(MustNewNamedConstructor("name", func() string { return "test" }))
+	ctr.MustContribute(MustNewConstructor(func() int8 { return 2 }))
+	return ctr
+}
+
+func TestConstructor__ParameterObject(t *testing.T) {
+	ctor := MustNewConstructor(func(u uint, params testInParams) int64 {
+		return int64(u) + int64(params.Value) + int64(len(params.Name)) + int64(params.Handlers[0])
+	})
+	t.Logf("%+v", ctor.Parameters())
+	expected := []reflect.Type{
+		reflect.TypeOf(uint(0)),
+		reflect.TypeOf(0),
+		kinit.Named(reflect.TypeOf(""), "name"),
+		kinit.Optional(reflect.TypeOf(0.0)),
+		kinit.Group(reflect.TypeOf(int8(0))),
+	}
+	if !reflect.DeepEqual(ctor.Parameters(), expected) {
+		t.Fail()
+		return
+	}
+	ctr := newTestInContainer()
+	ctr.MustProvide(MustNewConstructor(func() uint { return 3 }))
+	ctr.MustProvide(ctor)
+	ctr.MustRun(MustNewFunctor(func(v int64) {
+		if v != 10 {
+			t.Fail()
+		}
+	}))
+}
+
+func TestProcessor__ParameterObject(t *testing.T) {
+	ctr := newTestInContainer()
+	ctr.MustProvide(MustNewConstructor(func() *int64 { return new(int64) }))
+	ctr.MustAttach(MustNewProcessor(func(v *int64, params testInParams) {
+		*v = int64(params.Value)
+	}))
+	ctr.MustRun(MustNewFunctor(func(v *int64) {
+		if *v != 1 {
+			t.Fail()
+		}
+	}))
+}
+
+func TestFunctor__ParameterObject(t *testing.T) {
+	ctr := newTestInContainer()
+	var called bool
+	ctr.MustRun(MustNewFunctor(func(params testInParams) {
+		called = params.Value == 1 && params.Name == "test" && params.Ratio == 0 && len(params.Handlers) == 1
+	}))
+	if !called {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewConstructor__WrongParameterObject(t *testing.T) {
+	_, err := NewConstructor(func(struct {
+		In
+		Value int `kinit:"unknown"`
+	}) int64 {
+		return 0
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
 )
 
 // Initializer represents a constructor based on a struct.
@@ -13,13 +14,34 @@ type Initializer struct {
 	t reflect.Type
 	// assignableFieldTypes specifies types of assignable struct fields.
 	assignableFieldTypes []reflect.Type
-	// assignableFieldIndexes specifies indexes of assignable struct fields.
-	assignableFieldIndexes []int
+	// assignableFieldDependencies specifies types of dependencies assignable struct fields are injected with.
+	assignableFieldDependencies []reflect.Type
+	// assignableFieldOptionals specifies whether dependencies of assignable struct fields are optional.
+	assignableFieldOptionals []bool
+	// assignableFieldIndexes specifies index sequences of assignable struct fields
+	// (sequences are longer than one for fields of flattened embedded structs).
+	assignableFieldIndexes [][]int
+	// desc specifies the description of this initializer.
+	desc kinit.Description
 }
 
 // NewInitializer returns a new initializer.
 //
 // The argument x must be a struct or a struct pointer.
+//
+// Each exported struct field will be injected with a dependency of the field type.
+// The field tag with the "kinit" key may control the injection of a field:
+//
+//     Replica  *sql.DB          `kinit:"name=replica"`
+//     Tracer   *Tracer          `kinit:"optional"`
+//     Handlers []http.Handler   `kinit:"group=handlers"`
+//     Cache    *Cache           `kinit:"-"`
+//
+// Unexported fields are never injected, thus they may only have the "-" tag.
+//
+// Exported fields of an embedded struct (or struct pointer) will be injected recursively instead
+// of the embedded field itself when it has the "flatten" tag. Two injected fields with the same name
+// are considered conflicting since only one of them can be promoted.
 func NewInitializer(x interface{}) (*Initializer, error) {
 	if x == nil {
 		return nil, kerror.New(kerror.EViolation, "struct or struct pointer expected, nil given")
@@ -38,17 +60,61 @@ func NewInitializer(x interface{}) (*Initializer, error) {
 		}
 	}
 	i := &Initializer{
-		t: t,
+		t:    t,
+		desc: describeType(t),
 	}
+	if err := i.addFields(st, nil, "", make(map[string]string)); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// addFields adds assignable fields of the given struct type to this initializer.
+//
+// Fields of the struct type are identified by given index and path prefixes,
+// fields of flattened embedded structs are added recursively. Paths of already added fields
+// are kept by their names to report conflicts between promoted fields.
+func (i *Initializer) addFields(st reflect.Type, index []int, prefix string, paths map[string]string) error {
 	for j, n := 0, st.NumField(); j < n; j++ {
 		sf := st.Field(j)
+		tag, err := parseFieldTag(sf)
+		if err != nil {
+			return err
+		}
+		if tag.skip || (sf.Anonymous && sf.Type == inType) {
+			continue
+		}
 		if sf.PkgPath != "" {
+			if _, ok := sf.Tag.Lookup(tagKey); ok {
+				return kerror.Newf(kerror.EViolation, "unexported field %s%s cannot be injected", prefix, sf.Name)
+			}
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), j)
+		if tag.flatten {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if !sf.Anonymous || ft.Kind() != reflect.Struct {
+				return kerror.Newf(kerror.EViolation, "field %s%s is not an embedded struct and cannot be flattened",
+					prefix, sf.Name)
+			}
+			if err := i.addFields(ft, fieldIndex, prefix+sf.Name+".", paths); err != nil {
+				return err
+			}
 			continue
 		}
+		if path, ok := paths[sf.Name]; ok {
+			return kerror.Newf(kerror.EViolation, "field %s%s conflicts with field %s", prefix, sf.Name, path)
+		}
+		paths[sf.Name] = prefix + sf.Name
 		i.assignableFieldTypes = append(i.assignableFieldTypes, sf.Type)
-		i.assignableFieldIndexes = append(i.assignableFieldIndexes, j)
+		i.assignableFieldDependencies = append(i.assignableFieldDependencies, tag.dependencyType(sf))
+		i.assignableFieldOptionals = append(i.assignableFieldOptionals, tag.optional)
+		i.assignableFieldIndexes = append(i.assignableFieldIndexes, fieldIndex)
 	}
-	return i, nil
+	return nil
 }
 
 // MustNewInitializer is a variant of the NewInitializer that panics on error.
@@ -73,8 +139,8 @@ func (i *Initializer) Parameters() []reflect.Type {
 	if i == nil {
 		return nil
 	}
-	types := make([]reflect.Type, len(i.assignableFieldTypes))
-	copy(types, i.assignableFieldTypes)
+	types := make([]reflect.Type, len(i.assignableFieldDependencies))
+	copy(types, i.assignableFieldDependencies)
 	return types
 }
 
@@ -89,10 +155,10 @@ func (i *Initializer) Create(a ...reflect.Value) (reflect.Value, kdone.Destructo
 			i.t, len(i.assignableFieldTypes), len(a))
 	}
 	for j, v := range a {
-		if v.Type() != i.assignableFieldTypes[j] {
+		if at := kinit.Actual(i.assignableFieldDependencies[j]); v.Type() != at {
 			return reflect.Value{}, nil, kerror.Newf(kerror.EViolation,
 				"%s initializer expects argument %d to be of %s type, %s given",
-				i.t, j+1, i.assignableFieldTypes[j], v.Type())
+				i.t, j+1, at, v.Type())
 		}
 	}
 	var sp, obj reflect.Value
@@ -105,7 +171,36 @@ func (i *Initializer) Create(a ...reflect.Value) (reflect.Value, kdone.Destructo
 	}
 	sv := sp.Elem()
 	for j, v := range a {
-		sv.Field(i.assignableFieldIndexes[j]).Set(v)
+		if i.assignableFieldOptionals[j] {
+			// Absent optional dependencies leave fields with zero values.
+			v = v.Field(0)
+		}
+		fieldByIndex(sv, i.assignableFieldIndexes[j]).Set(v)
 	}
 	return obj, kdone.Noop, nil
 }
+
+// Describe implements the kinit.Describer interface.
+func (i *Initializer) Describe() kinit.Description {
+	if i == nil {
+		return kinit.Description{}
+	}
+	return i.desc
+}
+
+// fieldByIndex returns the nested field of the given struct corresponding to the given index sequence.
+//
+// Nil pointers to embedded structs are replaced with pointers to new structs.
+func fieldByIndex(sv reflect.Value, index []int) reflect.Value {
+	v := sv
+	for k, j := range index {
+		if k > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(j)
+	}
+	return v
+}
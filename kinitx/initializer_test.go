@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+	"github.com/go-kata/kinit/kinitq"
 )
 
 type testInitializerT1 struct{}
@@ -141,3 +143,212 @@ func TestNilInitializer_Create(t *testing.T) {
 		return
 	}
 }
+
+type testInitializerT4 struct {
+	Obj1 *testInitializerT1 `kinit:"name=first"`
+	Obj2 *testInitializerT1 `kinit:"name=second"`
+}
+
+func TestInitializer__NamedFields(t *testing.T) {
+	ctor := MustNewInitializer((*testInitializerT4)(nil))
+	t.Logf("%+v %+v", ctor.Type(), ctor.Parameters())
+	params := ctor.Parameters()
+	t1 := reflect.TypeOf((*testInitializerT1)(nil))
+	if len(params) != 2 || params[0] != kinit.Named(t1, "first") || params[1] != kinit.Named(t1, "second") {
+		t.Fail()
+		return
+	}
+	obj1 := &testInitializerT1{}
+	obj2 := &testInitializerT1{}
+	o4, _, err := ctor.Create(reflect.ValueOf(obj1), reflect.ValueOf(obj2))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if obj4 := o4.Interface().(*testInitializerT4); obj4.Obj1 != obj1 || obj4.Obj2 != obj2 {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewInitializer__WrongTag(t *testing.T) {
+	_, err := NewInitializer(struct {
+		Obj *testInitializerT1 `kinit:"unknown"`
+	}{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+type testInitializerT5 struct {
+	Obj1 func() (*testInitializerT1, error) `kinit:"name=first"`
+}
+
+func TestInitializer__LazyFields(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewNamedConstructor("first", func() *testInitializerT1 {
+		return &testInitializerT1{}
+	}))
+	ctr.MustProvide(MustNewInitializer((*testInitializerT5)(nil)))
+	ctr.MustRun(MustNewFunctor(func(obj5 *testInitializerT5) error {
+		obj1, err := obj5.Obj1()
+		if err != nil {
+			return err
+		}
+		if obj1 == nil {
+			t.Fail()
+		}
+		return nil
+	}))
+}
+
+type testInitializerT6 struct {
+	Obj1 struct {
+		Value   *testInitializerT1
+		Present bool
+	}
+}
+
+func TestInitializer__OptionalFields(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewInitializer((*testInitializerT6)(nil)))
+	ctr.MustRun(MustNewFunctor(func(obj6 *testInitializerT6) {
+		if obj6.Obj1.Present || obj6.Obj1.Value != nil {
+			t.Fail()
+		}
+	}))
+}
+
+type testInitializerT7 struct {
+	Obj1     *testInitializerT1   `kinit:"-"`
+	Obj2     *testInitializerT2   `kinit:"optional"`
+	Obj3     *testInitializerT3   `kinit:"optional,name=third"`
+	Handlers []*testInitializerT1 `kinit:"group=handlers"`
+	obj4     *testInitializerT1   `kinit:"-"`
+}
+
+func TestInitializer__TaggedFields(t *testing.T) {
+	ctor := MustNewInitializer((*testInitializerT7)(nil))
+	t.Logf("%+v %+v", ctor.Type(), ctor.Parameters())
+	params := ctor.Parameters()
+	t1 := reflect.TypeOf((*testInitializerT1)(nil))
+	t2 := reflect.TypeOf((*testInitializerT2)(nil))
+	t3 := reflect.TypeOf((*testInitializerT3)(nil))
+	if len(params) != 3 || params[0] != kinit.Optional(t2) || params[1] != kinit.Optional(kinit.Named(t3, "third")) ||
+		params[2] != kinit.Group(kinit.Named(t1, "handlers")) {
+		t.Fail()
+		return
+	}
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(ctor)
+	ctr.MustProvide(MustNewConstructor(func() *testInitializerT2 { return &testInitializerT2{} }))
+	ctr.MustContribute(MustNewNamedConstructor("handlers", func() *testInitializerT1 { return &testInitializerT1{} }))
+	if err := kinitq.NewInspector().Inspect(ctr, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	ctr.MustRun(MustNewFunctor(func(obj7 *testInitializerT7) {
+		if obj7.Obj1 != nil || obj7.Obj2 == nil || obj7.Obj3 != nil || len(obj7.Handlers) != 1 {
+			t.Fail()
+		}
+	}))
+}
+
+func TestNewInitializer__UnexportedTaggedField(t *testing.T) {
+	_, err := NewInitializer(struct {
+		obj *testInitializerT1 `kinit:"optional"`
+	}{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewInitializer__WrongGroupTag(t *testing.T) {
+	_, err := NewInitializer(struct {
+		Obj *testInitializerT1 `kinit:"group=handlers"`
+	}{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewInitializer__NamedGroupTag(t *testing.T) {
+	_, err := NewInitializer(struct {
+		Objs []*testInitializerT1 `kinit:"group=handlers,name=first"`
+	}{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+type TestInitializerDeps struct {
+	Obj1 *testInitializerT1
+	Obj2 *testInitializerT2 `kinit:"optional"`
+}
+
+type TestInitializerNestedDeps struct {
+	TestInitializerDeps `kinit:"flatten"`
+}
+
+type testInitializerT8 struct {
+	*TestInitializerNestedDeps `kinit:"flatten"`
+	Obj3                       *testInitializerT3
+}
+
+func TestInitializer__FlattenedFields(t *testing.T) {
+	ctor := MustNewInitializer(testInitializerT8{})
+	t.Logf("%+v %+v", ctor.Type(), ctor.Parameters())
+	params := ctor.Parameters()
+	t1 := reflect.TypeOf((*testInitializerT1)(nil))
+	t2 := reflect.TypeOf((*testInitializerT2)(nil))
+	t3 := reflect.TypeOf((*testInitializerT3)(nil))
+	if len(params) != 3 || params[0] != t1 || params[1] != kinit.Optional(t2) || params[2] != t3 {
+		t.Fail()
+		return
+	}
+	obj1 := &testInitializerT1{}
+	obj3 := &testInitializerT3{}
+	o8, _, err := ctor.Create(reflect.ValueOf(obj1), reflect.Zero(kinit.Optional(t2)), reflect.ValueOf(obj3))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if obj8 := o8.Interface().(testInitializerT8); obj8.Obj1 != obj1 || obj8.Obj2 != nil || obj8.Obj3 != obj3 {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewInitializer__ConflictingFlattenedFields(t *testing.T) {
+	_, err := NewInitializer(struct {
+		TestInitializerDeps `kinit:"flatten"`
+		Obj1                *testInitializerT1
+	}{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewInitializer__WrongFlattenTag(t *testing.T) {
+	_, err := NewInitializer(struct {
+		Deps TestInitializerDeps `kinit:"flatten"`
+	}{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
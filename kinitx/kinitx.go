@@ -2,6 +2,7 @@
 package kinitx
 
 import (
+	"context"
 	"io"
 	"reflect"
 
@@ -44,12 +45,15 @@ var runtimeType = reflect.TypeOf((*kinit.Runtime)(nil))
 // - if x is a struct or pointer it will be parsed using the NewInitializer;
 //
 // - all other variants of x are unacceptable.
+//
+// If the constructor creates result objects (see the Out) their fields will be provided too
+// (see the ProvideExpanded).
 func Provide(x interface{}) error {
 	ctor, err := castToConstructor(x)
 	if err != nil {
 		return err
 	}
-	return kinit.Global().Provide(ctor)
+	return provide(ctor)
 }
 
 // MustProvide is a variant of the Provide that panics on error.
@@ -59,6 +63,151 @@ func MustProvide(x interface{}) {
 	}
 }
 
+// ProvideExpanded calls the ProvideAll method of the given container by passing the given constructor
+// and results providing fields of result objects it creates (see the NewResults).
+//
+// Either all of them are registered or none: if some of them cannot be registered
+// (e.g. a constructor for the type of some field is already registered) the error will be returned
+// without registration of others.
+func ProvideExpanded(ctr *kinit.Container, ctor kinit.Constructor) error {
+	results, err := NewResults(ctor)
+	if err != nil {
+		return err
+	}
+	ctors := []kinit.Constructor{ctor}
+	for _, r := range results {
+		ctors = append(ctors, r)
+	}
+	return ctr.ProvideAll(ctors...)
+}
+
+// MustProvideExpanded is a variant of the ProvideExpanded that panics on error.
+func MustProvideExpanded(ctr *kinit.Container, ctor kinit.Constructor) {
+	if err := ProvideExpanded(ctr, ctor); err != nil {
+		panic(err)
+	}
+}
+
+// provide calls the ProvideExpanded by passing the global container and the given constructor.
+func provide(ctor kinit.Constructor) error {
+	return ProvideExpanded(kinit.Global(), ctor)
+}
+
+// checkContribution checks that the given constructor may be registered as a group member.
+//
+// Constructors of result objects cannot be group members since their fields are provided separately.
+func checkContribution(ctor kinit.Constructor) error {
+	if t := ctor.Type(); t != nil && isResultObject(kinit.Actual(t)) {
+		return kerror.Newf(kerror.EViolation, "result object %s cannot be a group member", t)
+	}
+	return nil
+}
+
+// ProvideNamed calls the Provide method of the global container by passing a constructor
+// based on the given entity that creates objects of a type qualified by the given name.
+//
+// See the documentation for the Provide to find out possible values of the argument x
+// and the handling of result objects.
+func ProvideNamed(name string, x interface{}) error {
+	ctor, err := NewNamedConstructor(name, x)
+	if err != nil {
+		return err
+	}
+	return provide(ctor)
+}
+
+// MustProvideNamed is a variant of the ProvideNamed that panics on error.
+func MustProvideNamed(name string, x interface{}) {
+	if err := ProvideNamed(name, x); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideScoped calls the Provide method of the global container by passing a constructor
+// based on the given entity that creates objects of the given scope (see the kinit.Scope).
+//
+// See the documentation for the Provide to find out possible values of the argument x
+// and the handling of result objects.
+func ProvideScoped(scope kinit.Scope, x interface{}) error {
+	ctor, err := NewScopedConstructor(scope, x)
+	if err != nil {
+		return err
+	}
+	return provide(ctor)
+}
+
+// MustProvideScoped is a variant of the ProvideScoped that panics on error.
+func MustProvideScoped(scope kinit.Scope, x interface{}) {
+	if err := ProvideScoped(scope, x); err != nil {
+		panic(err)
+	}
+}
+
+// Contribute calls the Contribute method of the global container by passing a constructor based on the given entity.
+//
+// See the documentation for the Provide to find out possible values of the argument x.
+// Constructors of result objects (see the Out) cannot be group members.
+func Contribute(x interface{}) error {
+	ctor, err := castToConstructor(x)
+	if err != nil {
+		return err
+	}
+	if err := checkContribution(ctor); err != nil {
+		return err
+	}
+	return kinit.Global().Contribute(ctor)
+}
+
+// MustContribute is a variant of the Contribute that panics on error.
+func MustContribute(x interface{}) {
+	if err := Contribute(x); err != nil {
+		panic(err)
+	}
+}
+
+// ProvideInto calls the ProvideInto method of the global container by passing given key
+// and a constructor based on the given entity.
+//
+// See the documentation for the Provide to find out possible values of the argument x.
+// Constructors of result objects (see the Out) cannot be group members.
+func ProvideInto(key string, x interface{}) error {
+	ctor, err := castToConstructor(x)
+	if err != nil {
+		return err
+	}
+	if err := checkContribution(ctor); err != nil {
+		return err
+	}
+	return kinit.Global().ProvideInto(key, ctor)
+}
+
+// MustProvideInto is a variant of the ProvideInto that panics on error.
+func MustProvideInto(key string, x interface{}) {
+	if err := ProvideInto(key, x); err != nil {
+		panic(err)
+	}
+}
+
+// DeclareGroup calls the DeclareGroup method of the global container by passing the type of the group
+// referred by the given pointer, e.g. (*map[string]Driver)(nil).
+func DeclareGroup(x interface{}) error {
+	if x == nil {
+		return kerror.New(kerror.EViolation, "group pointer expected, nil given")
+	}
+	pt := reflect.TypeOf(x)
+	if pt.Kind() != reflect.Ptr {
+		return kerror.Newf(kerror.EViolation, "group pointer expected, %s given", pt)
+	}
+	return kinit.Global().DeclareGroup(pt.Elem())
+}
+
+// MustDeclareGroup is a variant of the DeclareGroup that panics on error.
+func MustDeclareGroup(x interface{}) {
+	if err := DeclareGroup(x); err != nil {
+		panic(err)
+	}
+}
+
 // Bind calls the Provide method of the global container bu passing a binder based on given interface and object.
 //
 // See the documentation for the NewBinder to find out possible values of the argument x.
@@ -101,6 +250,44 @@ func MustAttach(x interface{}) {
 	}
 }
 
+// AttachNamed calls the Attach method of the global container by passing a processor
+// based on the given entity that processes objects of a type qualified by the given name.
+//
+// See the documentation for the Attach to find out possible values of the argument x.
+func AttachNamed(name string, x interface{}) error {
+	proc, err := NewNamedProcessor(name, x)
+	if err != nil {
+		return err
+	}
+	return kinit.Global().Attach(proc)
+}
+
+// MustAttachNamed is a variant of the AttachNamed that panics on error.
+func MustAttachNamed(name string, x interface{}) {
+	if err := AttachNamed(name, x); err != nil {
+		panic(err)
+	}
+}
+
+// AttachSequenced calls the Attach method of the global container by passing a processor based on
+// the given entity that is called after processors labeled by labels listed in the argument after.
+//
+// See the documentation for the NewSequencedProcessor to find out possible values of arguments.
+func AttachSequenced(label string, after []string, x interface{}) error {
+	proc, err := NewSequencedProcessor(label, after, x)
+	if err != nil {
+		return err
+	}
+	return kinit.Global().Attach(proc)
+}
+
+// MustAttachSequenced is a variant of the AttachSequenced that panics on error.
+func MustAttachSequenced(label string, after []string, x interface{}) {
+	if err := AttachSequenced(label, after, x); err != nil {
+		panic(err)
+	}
+}
+
 // Run calls the Run method of the global container by passing functors based on given entities.
 //
 // Items of the xx argument (let's name each item as x) will be parsed corresponding to following rules:
@@ -129,6 +316,60 @@ func MustRun(xx ...interface{}) {
 	}
 }
 
+// RunContext calls the RunContext method of the global container by passing the given context
+// and functors based on given entities.
+//
+// Functions may declare the context.Context parameter to receive the given context.
+// See the documentation for the Run to find out possible values of items of the argument xx.
+func RunContext(ctx context.Context, xx ...interface{}) error {
+	functors := make([]kinit.Functor, len(xx))
+	for i, x := range xx {
+		fun, err := castToFunctor(x)
+		if err != nil {
+			return err
+		}
+		functors[i] = fun
+	}
+	return kinit.Global().RunContext(ctx, functors...)
+}
+
+// MustRunContext is a variant of the RunContext that panics on error.
+func MustRunContext(ctx context.Context, xx ...interface{}) {
+	if err := RunContext(ctx, xx...); err != nil {
+		panic(err)
+	}
+}
+
+// Resolve calls the Resolve method of the given runtime by passing the type the given pointer points to
+// and stores the resolved object into the pointed variable.
+//
+// The argument target must be a non-nil pointer.
+func Resolve(rt *kinit.Runtime, target interface{}) error {
+	if target == nil {
+		return kerror.New(kerror.EViolation, "pointer expected, nil given")
+	}
+	pv := reflect.ValueOf(target)
+	if pv.Kind() != reflect.Ptr {
+		return kerror.Newf(kerror.EViolation, "pointer expected, %s given", pv.Type())
+	}
+	if pv.IsNil() {
+		return kerror.New(kerror.EViolation, "pointer expected, nil given")
+	}
+	obj, err := rt.Resolve(pv.Type().Elem())
+	if err != nil {
+		return err
+	}
+	pv.Elem().Set(obj)
+	return nil
+}
+
+// MustResolve is a variant of the Resolve that panics on error.
+func MustResolve(rt *kinit.Runtime, target interface{}) {
+	if err := Resolve(rt, target); err != nil {
+		panic(err)
+	}
+}
+
 // Require calls the Require method of the global inspector by passing the type of the given entity.
 //
 // The argument x must not be nil.
@@ -163,8 +404,8 @@ func MustIgnore(x interface{}) {
 	}
 }
 
-// Consider calls the Require method of the global inspector
-// for each dependency of the functor based on the given entity.
+// Consider calls the Consider method of the global inspector
+// by passing the functor based on the given entity.
 //
 // See the documentation for the Run to find out possible values of the argument x.
 func Consider(x interface{}) error {
@@ -172,12 +413,7 @@ func Consider(x interface{}) error {
 	if err != nil {
 		return err
 	}
-	for _, p := range fun.Parameters() {
-		if err := kinitq.Global().Require(p); err != nil {
-			return err
-		}
-	}
-	return nil
+	return kinitq.Global().Consider(fun)
 }
 
 // MustConsider is a variant of the Consider that panics on error.
@@ -198,3 +434,34 @@ func MustInspect(opt *kinitq.Options) {
 		panic(err)
 	}
 }
+
+// InspectJSON calls the Inspect method of the global inspector on the global container
+// and writes the report on the inspection result to the given writer in the JSON format
+// (see the kinitq.WriteJSONReport).
+//
+// The inspection error is returned along with the writing one.
+func InspectJSON(w io.Writer, opt *kinitq.Options) error {
+	err := Inspect(opt)
+	return kerror.Join(err, kinitq.WriteJSONReport(w, err))
+}
+
+// MustInspectJSON is a variant of the InspectJSON that panics on error.
+func MustInspectJSON(w io.Writer, opt *kinitq.Options) {
+	if err := InspectJSON(w, opt); err != nil {
+		panic(err)
+	}
+}
+
+// Graph calls the Graph method of the global inspector on the global container.
+func Graph() (*kinitq.Graph, error) {
+	return kinitq.Global().Graph(kinit.Global())
+}
+
+// MustGraph is a variant of the Graph that panics on error.
+func MustGraph() *kinitq.Graph {
+	g, err := Graph()
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
@@ -1,9 +1,13 @@
 package kinitx
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
 )
 
 func TestProvide__Nil(t *testing.T) {
@@ -27,6 +31,108 @@ func TestMustProvide__Nil(t *testing.T) {
 	}
 }
 
+func TestProvideNamed__Nil(t *testing.T) {
+	err := ProvideNamed("name", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustProvideNamed__Nil(t *testing.T) {
+	err := kerror.Try(func() error {
+		MustProvideNamed("name", nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestProvideScoped__Nil(t *testing.T) {
+	err := ProvideScoped("scope", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustProvideScoped__Nil(t *testing.T) {
+	err := kerror.Try(func() error {
+		MustProvideScoped("scope", nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestContribute__Nil(t *testing.T) {
+	err := Contribute(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustContribute__Nil(t *testing.T) {
+	err := kerror.Try(func() error {
+		MustContribute(nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestProvideInto__Nil(t *testing.T) {
+	err := ProvideInto("key", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustProvideInto__Nil(t *testing.T) {
+	err := kerror.Try(func() error {
+		MustProvideInto("key", nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestDeclareGroup__Nil(t *testing.T) {
+	err := DeclareGroup(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestDeclareGroup__NotPointer(t *testing.T) {
+	err := DeclareGroup([]int{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
 func TestBind__NilInterfacePointer(t *testing.T) {
 	err := Bind(nil, 0)
 	t.Logf("%+v", err)
@@ -69,6 +175,48 @@ func TestMustAttach__Nil(t *testing.T) {
 	}
 }
 
+func TestAttachNamed__Nil(t *testing.T) {
+	err := AttachNamed("name", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustAttachNamed__Nil(t *testing.T) {
+	err := kerror.Try(func() error {
+		MustAttachNamed("name", nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestAttachSequenced__Nil(t *testing.T) {
+	err := AttachSequenced("label", nil, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustAttachSequenced__Nil(t *testing.T) {
+	err := kerror.Try(func() error {
+		MustAttachSequenced("label", nil, nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
 func TestRun__Nil(t *testing.T) {
 	err := Run(nil)
 	t.Logf("%+v", err)
@@ -90,6 +238,75 @@ func TestMustRun__Nil(t *testing.T) {
 	}
 }
 
+func TestRunContext__Nil(t *testing.T) {
+	err := RunContext(context.Background(), nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustRunContext__Nil(t *testing.T) {
+	err := kerror.Try(func() error {
+		MustRunContext(context.Background(), nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestResolve(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewConstructor(func() *int {
+		value := 42
+		return &value
+	}))
+	arena := kinit.NewArena()
+	defer arena.MustFinalize()
+	rt := kinit.MustNewRuntime(ctr, arena)
+	var value *int
+	if err := Resolve(rt, &value); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if value == nil || *value != 42 {
+		t.Fail()
+		return
+	}
+}
+
+func TestResolve__NilPointer(t *testing.T) {
+	arena := kinit.NewArena()
+	defer arena.MustFinalize()
+	rt := kinit.MustNewRuntime(kinit.NewContainer(), arena)
+	err := Resolve(rt, (*int)(nil))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestMustResolve__NilPointer(t *testing.T) {
+	arena := kinit.NewArena()
+	defer arena.MustFinalize()
+	rt := kinit.MustNewRuntime(kinit.NewContainer(), arena)
+	err := kerror.Try(func() error {
+		MustResolve(rt, nil)
+		return nil
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
 func TestRequire__Nil(t *testing.T) {
 	err := Require(nil)
 	t.Logf("%+v", err)
@@ -171,3 +388,36 @@ func TestMustInspect(t *testing.T) {
 		return
 	}
 }
+
+func TestGraph(t *testing.T) {
+	if _, err := Graph(); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestMustGraph(t *testing.T) {
+	if err := kerror.Try(func() error {
+		MustGraph()
+		return nil
+	}); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestInspectJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := InspectJSON(&buf, nil); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Log(buf.String())
+		t.Fail()
+		return
+	}
+}
@@ -0,0 +1,159 @@
+package kinitx
+
+import (
+	"reflect"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// NamedConstructor represents a constructor that creates objects of a type qualified by a name.
+type NamedConstructor struct {
+	// t specifies the qualified type of an object that is created by this constructor.
+	t reflect.Type
+	// ctor specifies the underlying constructor.
+	ctor kinit.Constructor
+}
+
+// NewNamedConstructor returns a new named constructor.
+//
+// The name must not be empty. See the documentation for the Provide
+// to find out possible values of the argument x.
+func NewNamedConstructor(name string, x interface{}) (*NamedConstructor, error) {
+	if name == "" {
+		return nil, kerror.New(kerror.EViolation, "name expected, empty string given")
+	}
+	ctor, err := castToConstructor(x)
+	if err != nil {
+		return nil, err
+	}
+	t := ctor.Type()
+	if t == nil {
+		return nil, kerror.New(kerror.EInvalid, "constructor for nil type cannot be named")
+	}
+	return &NamedConstructor{
+		t:    kinit.Named(t, name),
+		ctor: ctor,
+	}, nil
+}
+
+// MustNewNamedConstructor is a variant of the NewNamedConstructor that panics on error.
+func MustNewNamedConstructor(name string, x interface{}) *NamedConstructor {
+	c, err := NewNamedConstructor(name, x)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Type implements the kinit.Constructor interface.
+func (c *NamedConstructor) Type() reflect.Type {
+	if c == nil {
+		return nil
+	}
+	return c.t
+}
+
+// Parameters implements the kinit.Constructor interface.
+func (c *NamedConstructor) Parameters() []reflect.Type {
+	if c == nil {
+		return nil
+	}
+	return c.ctor.Parameters()
+}
+
+// Create implements the kinit.Constructor interface.
+func (c *NamedConstructor) Create(a ...reflect.Value) (reflect.Value, kdone.Destructor, error) {
+	if c == nil {
+		return reflect.Value{}, kdone.Noop, nil
+	}
+	return c.ctor.Create(a...)
+}
+
+// Scope implements the kinit.Scoper interface.
+func (c *NamedConstructor) Scope() kinit.Scope {
+	if c == nil {
+		return ""
+	}
+	return kinit.ScopeOf(c.ctor)
+}
+
+// Describe implements the kinit.Describer interface.
+func (c *NamedConstructor) Describe() kinit.Description {
+	if c == nil {
+		return kinit.Description{}
+	}
+	return kinit.Describe(c.ctor)
+}
+
+// NamedProcessor represents a processor that processes objects of a type qualified by a name.
+type NamedProcessor struct {
+	// t specifies the qualified type of an object that is processed by this processor.
+	t reflect.Type
+	// proc specifies the underlying processor.
+	proc kinit.Processor
+}
+
+// NewNamedProcessor returns a new named processor.
+//
+// The name must not be empty. See the documentation for the Attach
+// to find out possible values of the argument x.
+func NewNamedProcessor(name string, x interface{}) (*NamedProcessor, error) {
+	if name == "" {
+		return nil, kerror.New(kerror.EViolation, "name expected, empty string given")
+	}
+	proc, err := castToProcessor(x)
+	if err != nil {
+		return nil, err
+	}
+	t := proc.Type()
+	if t == nil {
+		return nil, kerror.New(kerror.EInvalid, "processor for nil type cannot be named")
+	}
+	return &NamedProcessor{
+		t:    kinit.Named(t, name),
+		proc: proc,
+	}, nil
+}
+
+// MustNewNamedProcessor is a variant of the NewNamedProcessor that panics on error.
+func MustNewNamedProcessor(name string, x interface{}) *NamedProcessor {
+	p, err := NewNamedProcessor(name, x)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Type implements the kinit.Processor interface.
+func (p *NamedProcessor) Type() reflect.Type {
+	if p == nil {
+		return nil
+	}
+	return p.t
+}
+
+// Parameters implements the kinit.Processor interface.
+func (p *NamedProcessor) Parameters() []reflect.Type {
+	if p == nil {
+		return nil
+	}
+	return p.proc.Parameters()
+}
+
+// Process implements the kinit.Processor interface.
+func (p *NamedProcessor) Process(obj reflect.Value, a ...reflect.Value) error {
+	if p == nil {
+		return nil
+	}
+	return p.proc.Process(obj, a...)
+}
+
+// Describe implements the kinit.Describer interface.
+func (p *NamedProcessor) Describe() kinit.Description {
+	if p == nil {
+		return kinit.Description{}
+	}
+	return kinit.Describe(p.proc)
+}
@@ -0,0 +1,91 @@
+package kinitx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testNamedDB struct {
+	dsn string
+}
+
+type testNamedRepository struct {
+	Primary *testNamedDB `kinit:"name=primary"`
+	Replica *testNamedDB `kinit:"name=replica"`
+}
+
+func TestNamedConstructor(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewNamedConstructor("primary", func() *testNamedDB { return &testNamedDB{"primary"} }))
+	ctr.MustProvide(MustNewNamedConstructor("replica", func() *testNamedDB { return &testNamedDB{"replica"} }))
+	ctr.MustProvide(MustNewInitializer((*testNamedRepository)(nil)))
+	ctr.MustAttach(MustNewNamedProcessor("replica", func(db *testNamedDB) { db.dsn += "?readonly" }))
+	ctr.MustRun(MustNewFunctor(func(repo *testNamedRepository) error {
+		if repo.Primary.dsn != "primary" || repo.Replica.dsn != "replica?readonly" {
+			return kerror.Newf(kerror.EInvalid, "unexpected repository: %+v %+v", repo.Primary, repo.Replica)
+		}
+		return nil
+	}))
+}
+
+func TestNamedConstructor_Type(t *testing.T) {
+	ctor := MustNewNamedConstructor("primary", func() *testNamedDB { return nil })
+	t.Logf("%+v %+v", ctor.Type(), ctor.Parameters())
+	if ctor.Type() != kinit.Named(reflect.TypeOf((*testNamedDB)(nil)), "primary") {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewNamedConstructor__EmptyName(t *testing.T) {
+	_, err := NewNamedConstructor("", func() *testNamedDB { return nil })
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewNamedConstructor__Nil(t *testing.T) {
+	_, err := NewNamedConstructor("primary", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewNamedProcessor__EmptyName(t *testing.T) {
+	_, err := NewNamedProcessor("", func(*testNamedDB) {})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewNamedProcessor__Nil(t *testing.T) {
+	_, err := NewNamedProcessor("primary", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilNamedConstructor_Type(t *testing.T) {
+	if (*NamedConstructor)(nil).Type() != nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilNamedProcessor_Type(t *testing.T) {
+	if (*NamedProcessor)(nil).Type() != nil {
+		t.Fail()
+		return
+	}
+}
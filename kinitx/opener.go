@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kata/kdone"
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
 )
 
 // Opener represents a constructor based on a function that creates
@@ -15,13 +16,18 @@ type Opener struct {
 	t reflect.Type
 	// function specifies the reflection to a function value.
 	function reflect.Value
-	// inTypes specifies types of function input parameters.
+	// inTypes specifies types of dependencies function input parameters are expanded to.
 	inTypes []reflect.Type
+	// inObjects specifies initializers of parameter objects associated with indexes
+	// of function input parameters (nil if there are no parameter objects).
+	inObjects []*Initializer
 	// objectOutIndex specifies the index of a function output parameter that contains a created object.
 	objectOutIndex int
 	// errorOutIndex specifies the index of a function output parameter that contains an error.
 	// The value -1 means that a function doesn't return an error.
 	errorOutIndex int
+	// desc specifies the description of this opener.
+	desc kinit.Description
 }
 
 // NewOpener returns a new opener.
@@ -33,6 +39,7 @@ type Opener struct {
 //
 //     func(...) (C, error);
 //
+// Parameter objects (see the In) are expanded into dependencies of their fields.
 func NewOpener(x interface{}) (*Opener, error) {
 	if x == nil {
 		return nil, kerror.New(kerror.EViolation, "function expected, nil given")
@@ -47,14 +54,19 @@ func NewOpener(x interface{}) (*Opener, error) {
 	}
 	o := &Opener{
 		function: fv,
+		desc:     describeFunction(fv),
 	}
 	numIn := ft.NumIn()
 	if ft.IsVariadic() {
 		numIn--
 	}
-	o.inTypes = make([]reflect.Type, numIn)
+	params := make([]reflect.Type, numIn)
 	for i := 0; i < numIn; i++ {
-		o.inTypes[i] = ft.In(i)
+		params[i] = ft.In(i)
+	}
+	var err error
+	if o.inTypes, o.inObjects, err = expandParameters(params); err != nil {
+		return nil, err
 	}
 	switch ft.NumOut() {
 	default:
@@ -115,16 +127,19 @@ func (o *Opener) Create(a ...reflect.Value) (reflect.Value, kdone.Destructor, er
 			o.t, len(o.inTypes), len(a))
 	}
 	for i, v := range a {
-		if v.Type() != o.inTypes[i] {
+		if v.Type() != kinit.Actual(o.inTypes[i]) {
 			return reflect.Value{}, nil, kerror.Newf(kerror.EViolation,
 				"%s opener expects argument %d to be of %s type, %s given",
-				o.t, i+1, o.inTypes[i], v.Type())
+				o.t, i+1, kinit.Actual(o.inTypes[i]), v.Type())
 		}
 	}
-	out := o.function.Call(a)
+	in, err := collapseArguments(o.inObjects, a)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	out := o.function.Call(in)
 	obj := out[o.objectOutIndex]
 	dtor := kdone.DestructorFunc(obj.Interface().(io.Closer).Close)
-	var err error
 	if o.errorOutIndex >= 0 {
 		if v := out[o.errorOutIndex].Interface(); v != nil {
 			err = v.(error)
@@ -132,3 +147,11 @@ func (o *Opener) Create(a ...reflect.Value) (reflect.Value, kdone.Destructor, er
 	}
 	return obj, dtor, err
 }
+
+// Describe implements the kinit.Describer interface.
+func (o *Opener) Describe() kinit.Description {
+	if o == nil {
+		return kinit.Description{}
+	}
+	return o.desc
+}
@@ -0,0 +1,176 @@
+package kinitx
+
+import (
+	"reflect"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// Out represents a marker of result objects.
+//
+// A struct embedding the Out created by a constructor is a result object: each its exported field
+// is provided as a separately typed object by the Result pseudo-constructor (see the NewResults).
+// Thus a single call of the constructor creates several objects sharing one destructor:
+//
+//     type ClientResult struct {
+//         kinitx.Out
+//         Client   *Client
+//         Admin    *AdminClient
+//         ReadOnly *ReadOnlyClient `kinit:"name=replica"`
+//     }
+//
+//     func NewClient(config *Config) (ClientResult, kdone.Destructor, error) { ... }
+//
+// Fields of result objects support only the "-" and the "name=N" tag options. Fields without the name
+// are qualified by the name of the constructor if any (see the NewNamedConstructor).
+type Out struct{}
+
+// outType specifies the reflection to the Out type.
+var outType = reflect.TypeOf(Out{})
+
+// isResultObject returns boolean specifies whether the given type is a type of result objects.
+func isResultObject(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i, n := 0, t.NumField(); i < n; i++ {
+		if sf := t.Field(i); sf.Anonymous && sf.Type == outType {
+			return true
+		}
+	}
+	return false
+}
+
+// Result represents a pseudo-constructor that extracts a field of a result object.
+type Result struct {
+	// t specifies the type of an object that is created by this result.
+	t reflect.Type
+	// inType specifies the (maybe qualified) type of the result object.
+	inType reflect.Type
+	// index specifies the index of the extracted field.
+	index int
+	// scope specifies the scope of the result object.
+	scope kinit.Scope
+	// desc specifies the description of this result.
+	desc kinit.Description
+}
+
+// NewResults returns results providing fields of result objects created by the given constructor.
+//
+// An empty list will be returned if the given constructor doesn't create result objects.
+// Results have the same scope as the constructor (see the kinit.Scoper). If the constructor
+// creates result objects of a qualified type (see the NamedConstructor), types of fields
+// without the "name=N" tag option are qualified by the same name.
+func NewResults(ctor kinit.Constructor) ([]*Result, error) {
+	if ctor == nil {
+		return nil, kerror.New(kerror.EViolation, "constructor expected, nil given")
+	}
+	ot := ctor.Type()
+	if ot == nil {
+		return nil, kerror.New(kerror.EViolation, "constructor creates objects of nil type")
+	}
+	st := kinit.Actual(ot)
+	if !isResultObject(st) {
+		return []*Result{}, nil
+	}
+	name := kinit.NameOf(ot)
+	desc := kinit.Describe(ctor)
+	scope := kinit.ScopeOf(ctor)
+	var results []*Result
+	for i, n := 0, st.NumField(); i < n; i++ {
+		sf := st.Field(i)
+		tag, err := parseFieldTag(sf)
+		if err != nil {
+			return nil, err
+		}
+		if tag.skip || (sf.Anonymous && sf.Type == outType) {
+			continue
+		}
+		if sf.PkgPath != "" {
+			if _, ok := sf.Tag.Lookup(tagKey); ok {
+				return nil, kerror.Newf(kerror.EViolation, "unexported field %s cannot be provided", sf.Name)
+			}
+			continue
+		}
+		if tag.optional || tag.group || tag.flatten {
+			return nil, kerror.Newf(kerror.EViolation, "field %s of result object has unsupported tag", sf.Name)
+		}
+		rt := tag.dependencyType(sf)
+		if kinit.NameOf(rt) == "" {
+			rt = kinit.Named(rt, name)
+		}
+		results = append(results, &Result{
+			t:      rt,
+			inType: ot,
+			index:  i,
+			scope:  scope,
+			desc:   desc,
+		})
+	}
+	if len(results) == 0 {
+		return nil, kerror.Newf(kerror.EViolation, "result object %s has no fields to provide", ot)
+	}
+	return results, nil
+}
+
+// MustNewResults is a variant of the NewResults that panics on error.
+func MustNewResults(ctor kinit.Constructor) []*Result {
+	results, err := NewResults(ctor)
+	if err != nil {
+		panic(err)
+	}
+	return results
+}
+
+// Type implements the kinit.Constructor interface.
+func (r *Result) Type() reflect.Type {
+	if r == nil {
+		return nil
+	}
+	return r.t
+}
+
+// Parameters implements the kinit.Constructor interface.
+func (r *Result) Parameters() []reflect.Type {
+	if r == nil {
+		return nil
+	}
+	return []reflect.Type{r.inType}
+}
+
+// Create implements the kinit.Constructor interface.
+//
+// Extracted objects are destroyed along with result objects, thus the returned destructor does nothing.
+func (r *Result) Create(a ...reflect.Value) (reflect.Value, kdone.Destructor, error) {
+	if r == nil {
+		return reflect.Value{}, kdone.Noop, nil
+	}
+	if len(a) != 1 {
+		return reflect.Value{}, nil, kerror.Newf(kerror.EViolation,
+			"%s result expects %d argument(s), %d given", r.t, 1, len(a))
+	}
+	if at := kinit.Actual(r.inType); a[0].Type() != at {
+		return reflect.Value{}, nil, kerror.Newf(kerror.EViolation,
+			"%s result expects argument %d to be of %s type, %s given",
+			r.t, 1, at, a[0].Type())
+	}
+	return a[0].Field(r.index), kdone.Noop, nil
+}
+
+// Scope implements the kinit.Scoper interface.
+func (r *Result) Scope() kinit.Scope {
+	if r == nil {
+		return ""
+	}
+	return r.scope
+}
+
+// Describe implements the kinit.Describer interface.
+func (r *Result) Describe() kinit.Description {
+	if r == nil {
+		return kinit.Description{}
+	}
+	return r.desc
+}
@@ -0,0 +1,206 @@
+package kinitx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+	"github.com/go-kata/kinit/kinitq"
+)
+
+type testOutClient struct {
+	destroyed bool
+}
+
+type testOutAdmin struct {
+	client *testOutClient
+}
+
+type testOutResult struct {
+	Out
+	Client  *testOutClient
+	Admin   *testOutAdmin
+	Replica *testOutAdmin `kinit:"name=replica"`
+	Skipped int           `kinit:"-"`
+}
+
+func TestResult(t *testing.T) {
+	var created int
+	ctor := MustNewConstructor(func() (testOutResult, kdone.Destructor, error) {
+		created++
+		client := &testOutClient{}
+		return testOutResult{
+			Client:  client,
+			Admin:   &testOutAdmin{client},
+			Replica: &testOutAdmin{client},
+		}, kdone.DestructorFunc(func() error {
+			client.destroyed = true
+			return nil
+		}), nil
+	})
+	results := MustNewResults(ctor)
+	at := reflect.TypeOf((*testOutAdmin)(nil))
+	if len(results) != 3 || results[0].Type() != reflect.TypeOf((*testOutClient)(nil)) || results[1].Type() != at ||
+		results[2].Type() != kinit.Named(at, "replica") {
+		t.Fail()
+		return
+	}
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(ctor)
+	for _, r := range results {
+		ctr.MustProvide(r)
+	}
+	inspector := kinitq.NewInspector()
+	for _, r := range results {
+		inspector.MustRequire(r.Type())
+	}
+	if err := inspector.Inspect(ctr, &kinitq.Options{ReportUnused: true}); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	var client *testOutClient
+	ctr.MustRun(MustNewFunctor(func(c *testOutClient, admin *testOutAdmin) {
+		client = c
+		if admin.client != c {
+			t.Fail()
+		}
+	}))
+	if created != 1 || !client.destroyed {
+		t.Fail()
+		return
+	}
+}
+
+func TestProvideExpanded(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctor := MustNewNamedConstructor("primary", func() testOutResult { return testOutResult{} })
+	MustProvideExpanded(ctr, ctor)
+	for _, typ := range []reflect.Type{
+		ctor.Type(),
+		kinit.Named(reflect.TypeOf((*testOutClient)(nil)), "primary"),
+		kinit.Named(reflect.TypeOf((*testOutAdmin)(nil)), "primary"),
+		kinit.Named(reflect.TypeOf((*testOutAdmin)(nil)), "replica"),
+	} {
+		if c, _ := ctr.Lookup(typ); c == nil {
+			t.Logf("%s", typ)
+			t.Fail()
+			return
+		}
+	}
+}
+
+type testOutClientResult struct {
+	Out
+	Client *testOutClient
+}
+
+func TestProvideExpanded__Named(t *testing.T) {
+	ctr := kinit.NewContainer()
+	for _, name := range []string{"primary", "replica"} {
+		ctor := MustNewNamedConstructor(name, func() testOutClientResult { return testOutClientResult{} })
+		if err := ProvideExpanded(ctr, ctor); err != nil {
+			t.Logf("%+v", err)
+			t.Fail()
+			return
+		}
+		if c, _ := ctr.Lookup(kinit.Named(reflect.TypeOf((*testOutClient)(nil)), name)); c == nil {
+			t.Logf("%s", name)
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestProvideExpanded__Conflict(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewConstructor(func() *testOutAdmin { return nil }))
+	ctor := MustNewConstructor(func() testOutResult { return testOutResult{} })
+	err := ProvideExpanded(ctr, ctor)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+	if c, _ := ctr.Lookup(ctor.Type()); c != nil {
+		t.Fail()
+		return
+	}
+	if c, _ := ctr.Lookup(reflect.TypeOf((*testOutClient)(nil))); c != nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestContribute__ResultObject(t *testing.T) {
+	err := Contribute(func() testOutResult { return testOutResult{} })
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestProvideInto__ResultObject(t *testing.T) {
+	err := ProvideInto("key", func() testOutResult { return testOutResult{} })
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewResults__NotResultObject(t *testing.T) {
+	results, err := NewResults(MustNewConstructor(func() int { return 0 }))
+	if err != nil || len(results) != 0 {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestNewResults__WrongTag(t *testing.T) {
+	_, err := NewResults(MustNewConstructor(func() struct {
+		Out
+		Value int `kinit:"optional"`
+	} {
+		return struct {
+			Out
+			Value int `kinit:"optional"`
+		}{}
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewResults__Nil(t *testing.T) {
+	_, err := NewResults(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestResult_Create__WrongArgumentType(t *testing.T) {
+	results := MustNewResults(MustNewConstructor(func() testOutResult { return testOutResult{} }))
+	_, _, err := results[0].Create(reflect.ValueOf(0))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilResult_Create(t *testing.T) {
+	obj, dtor, err := (*Result)(nil).Create()
+	if obj.IsValid() || dtor == nil || err != nil {
+		t.Fail()
+		return
+	}
+}
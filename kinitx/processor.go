@@ -4,6 +4,7 @@ import (
 	"reflect"
 
 	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
 )
 
 // Processor represents a processor based on a function.
@@ -12,11 +13,16 @@ type Processor struct {
 	t reflect.Type
 	// function specifies the reflection to a function value.
 	function reflect.Value
-	// inTypes specifies types of function input parameters.
+	// inTypes specifies types of dependencies function input parameters are expanded to.
 	inTypes []reflect.Type
+	// inObjects specifies initializers of parameter objects associated with indexes
+	// of function input parameters (nil if there are no parameter objects).
+	inObjects []*Initializer
 	// errorOutIndex specifies the index of a function output parameter that contains an error.
 	// The value -1 means that a function doesn't return an error.
 	errorOutIndex int
+	// desc specifies the description of this processor.
+	desc kinit.Description
 }
 
 // NewProcessor returns a new processor.
@@ -28,6 +34,7 @@ type Processor struct {
 //
 //     func(T, ...) error.
 //
+// Parameter objects (see the In) are expanded into dependencies of their fields.
 func NewProcessor(x interface{}) (*Processor, error) {
 	if x == nil {
 		return nil, kerror.New(kerror.EViolation, "function expected, nil given")
@@ -42,6 +49,7 @@ func NewProcessor(x interface{}) (*Processor, error) {
 	}
 	p := &Processor{
 		function: fv,
+		desc:     describeFunction(fv),
 	}
 	numIn := ft.NumIn()
 	if ft.IsVariadic() {
@@ -51,9 +59,13 @@ func NewProcessor(x interface{}) (*Processor, error) {
 		return nil, kerror.Newf(kerror.EViolation, "function %s is not a processor", ft)
 	}
 	p.t = ft.In(0)
-	p.inTypes = make([]reflect.Type, numIn-1)
+	params := make([]reflect.Type, numIn-1)
 	for i := 1; i < numIn; i++ {
-		p.inTypes[i-1] = ft.In(i)
+		params[i-1] = ft.In(i)
+	}
+	var err error
+	if p.inTypes, p.inObjects, err = expandParameters(params); err != nil {
+		return nil, err
 	}
 	switch ft.NumOut() {
 	default:
@@ -109,18 +121,18 @@ func (p *Processor) Process(obj reflect.Value, a ...reflect.Value) error {
 		return kerror.Newf(kerror.EViolation,
 			"%s processor expects %d argument(s), %d given", p.t, len(p.inTypes), len(a))
 	}
-	in := make([]reflect.Value, len(p.inTypes)+1)
-	in[0] = obj
 	for i, v := range a {
-		if v.Type() != p.inTypes[i] {
+		if v.Type() != kinit.Actual(p.inTypes[i]) {
 			return kerror.Newf(kerror.EViolation,
 				"%s processor expects argument %d to be of %s type, %s given",
-				p.t, i+1, p.inTypes[i], v.Type())
+				p.t, i+1, kinit.Actual(p.inTypes[i]), v.Type())
 		}
-		in[i+1] = a[i]
 	}
-	out := p.function.Call(in)
-	var err error
+	in, err := collapseArguments(p.inObjects, a)
+	if err != nil {
+		return err
+	}
+	out := p.function.Call(append([]reflect.Value{obj}, in...))
 	if p.errorOutIndex >= 0 {
 		if v := out[p.errorOutIndex].Interface(); v != nil {
 			err = v.(error)
@@ -128,3 +140,11 @@ func (p *Processor) Process(obj reflect.Value, a ...reflect.Value) error {
 	}
 	return err
 }
+
+// Describe implements the kinit.Describer interface.
+func (p *Processor) Describe() kinit.Description {
+	if p == nil {
+		return kinit.Description{}
+	}
+	return p.desc
+}
@@ -0,0 +1,295 @@
+package kinitx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// ProfileEntry represents an entry of the startup profile.
+type ProfileEntry struct {
+	Timing
+	// Start specifies the moment an event has begun at.
+	Start time.Time
+	// Exclusive specifies the duration of an event excluding durations of nested events
+	// (e.g. the creation of an object without the creation of its dependencies).
+	//
+	// The Duration specifies the duration including durations of nested events.
+	Exclusive time.Duration
+	// Children specifies nested events sorted by the inclusive duration in the descending order.
+	Children []*ProfileEntry
+	// stop specifies the observed moment an event has ended at (zero if unknown).
+	stop time.Time
+}
+
+// end returns the moment an event of this entry has ended at.
+func (e *ProfileEntry) end() time.Time {
+	if !e.stop.IsZero() {
+		return e.stop
+	}
+	return e.Start.Add(e.Duration)
+}
+
+// Profile represents a tracer that records the wall-clock time of object creations and processings
+// (as well as destructions and functor calls) to build a startup profile.
+//
+// Events are nested by their time intervals, thus in the parallel mode the nesting of objects
+// created concurrently with the dependencies of another object is approximate.
+type Profile struct {
+	// mutex specifies the mutex that guards fields below.
+	mutex sync.Mutex
+	// entries specifies recorded entries in the order of their end.
+	entries []ProfileEntry
+	// starts specifies moments creations of objects of associated types have begun at
+	// in the order of their beginning.
+	starts map[reflect.Type][]time.Time
+	// now specifies the function returning the current time (nil means the time.Now).
+	now func() time.Time
+}
+
+// NewProfile returns a new profile.
+func NewProfile() *Profile {
+	return &Profile{}
+}
+
+// clock returns the current time.
+func (p *Profile) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// OnResolveStart implements the kinit.Tracer interface.
+func (p *Profile) OnResolveStart(typ reflect.Type) {
+	if p == nil {
+		return
+	}
+	start := p.clock()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.starts == nil {
+		p.starts = make(map[reflect.Type][]time.Time)
+	}
+	p.starts[typ] = append(p.starts[typ], start)
+}
+
+// OnResolveEnd implements the kinit.Tracer interface.
+func (p *Profile) OnResolveEnd(typ reflect.Type, ctor kinit.Constructor, d time.Duration, err error) {
+	if p == nil {
+		return
+	}
+	end := p.clock()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	start := end.Add(-d)
+	if starts := p.starts[typ]; len(starts) > 0 {
+		// Objects of the same type may be created concurrently only on different arenas,
+		// thus the earliest start is matched to the first end.
+		start = starts[0]
+		if len(starts) > 1 {
+			p.starts[typ] = starts[1:]
+		} else {
+			delete(p.starts, typ)
+		}
+	}
+	// The observed end is kept since the reported duration may be shorter than the observed interval,
+	// while events occurred during the creation must be nested in it.
+	p.entries = append(p.entries, ProfileEntry{
+		Timing: Timing{ConstructorTiming, typ, kinit.Describe(ctor), d, err},
+		Start:  start,
+		stop:   end,
+	})
+}
+
+// OnProcess implements the kinit.Tracer interface.
+func (p *Profile) OnProcess(typ reflect.Type, proc kinit.Processor, d time.Duration, err error) {
+	p.record(Timing{ProcessorTiming, typ, kinit.Describe(proc), d, err})
+}
+
+// OnDestroy implements the kinit.Tracer interface.
+func (p *Profile) OnDestroy(typ reflect.Type, d time.Duration, err error) {
+	p.record(Timing{DestructorTiming, typ, kinit.Description{}, d, err})
+}
+
+// OnFunctorCall implements the kinit.Tracer interface.
+func (p *Profile) OnFunctorCall(fun kinit.Functor, d time.Duration, err error) {
+	p.record(Timing{FunctorTiming, nil, kinit.Describe(fun), d, err})
+}
+
+// record records the given timing of an event that has just ended.
+//
+// The container reports such events right after their end, thus the start is derived from the duration.
+func (p *Profile) record(timing Timing) {
+	if p == nil {
+		return
+	}
+	start := p.clock().Add(-timing.Duration)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.entries = append(p.entries, ProfileEntry{
+		Timing: timing,
+		Start:  start,
+	})
+}
+
+// Entries returns the tree of recorded entries.
+//
+// Top-level entries are sorted by the start, nested ones are sorted by the inclusive duration in the descending order.
+func (p *Profile) Entries() []*ProfileEntry {
+	if p == nil {
+		return nil
+	}
+	p.mutex.Lock()
+	entries := make([]*ProfileEntry, len(p.entries))
+	for i := range p.entries {
+		entry := p.entries[i]
+		entries[i] = &entry
+	}
+	p.mutex.Unlock()
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Start.Equal(entries[j].Start) {
+			return entries[i].Start.Before(entries[j].Start)
+		}
+		return entries[i].Duration > entries[j].Duration
+	})
+	var roots, stack []*ProfileEntry
+	for _, entry := range entries {
+		for len(stack) > 0 && stack[len(stack)-1].end().Before(entry.end()) {
+			stack = stack[:len(stack)-1]
+		}
+		if n := len(stack); n > 0 {
+			stack[n-1].Children = append(stack[n-1].Children, entry)
+		} else {
+			roots = append(roots, entry)
+		}
+		stack = append(stack, entry)
+	}
+	for _, entry := range entries {
+		entry.Exclusive = entry.Duration
+		for _, child := range entry.Children {
+			entry.Exclusive -= child.Duration
+		}
+		if entry.Exclusive < 0 {
+			entry.Exclusive = 0
+		}
+		sort.SliceStable(entry.Children, func(i, j int) bool {
+			return entry.Children[i].Duration > entry.Children[j].Duration
+		})
+	}
+	return roots
+}
+
+// WriteTree writes the flame-style tree of recorded entries to the given writer.
+//
+// Each line of the tree contains the inclusive and exclusive durations, the kind and the subject of an event.
+func (p *Profile) WriteTree(w io.Writer) error {
+	if p == nil {
+		return kerror.New(kerror.ENil, "nil profile cannot write tree")
+	}
+	if _, err := fmt.Fprintf(w, "%12s  %12s  %s\n", "inclusive", "exclusive", "event"); err != nil {
+		return err
+	}
+	return writeProfileEntries(w, p.Entries(), 0)
+}
+
+// writeProfileEntries writes given entries of the given depth and their children to the given writer.
+func writeProfileEntries(w io.Writer, entries []*ProfileEntry, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	for _, entry := range entries {
+		if _, err := fmt.Fprintf(w, "%12s  %12s  %s%-11s  %s\n",
+			entry.Duration, entry.Exclusive, indent, entry.Kind, entry.subject()); err != nil {
+			return err
+		}
+		if err := writeProfileEntries(w, entry.Children, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chromeTraceEvent represents a complete event of the Chrome trace event format.
+type chromeTraceEvent struct {
+	// Name specifies the name of an event.
+	Name string `json:"name"`
+	// Category specifies the category of an event.
+	Category string `json:"cat"`
+	// Phase specifies the type of an event.
+	Phase string `json:"ph"`
+	// Timestamp specifies the start of an event in microseconds.
+	Timestamp float64 `json:"ts"`
+	// Duration specifies the duration of an event in microseconds.
+	Duration float64 `json:"dur"`
+	// Process specifies the process identifier.
+	Process int `json:"pid"`
+	// Thread specifies the thread identifier.
+	Thread int `json:"tid"`
+	// Args specifies arguments of an event.
+	Args map[string]string `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes recorded entries to the given writer in the Chrome trace event format
+// which can be viewed using the chrome://tracing or the Perfetto UI.
+//
+// Top-level entries overlapping in time (e.g. in the parallel mode) are placed to separate threads.
+func (p *Profile) WriteChromeTrace(w io.Writer) error {
+	if p == nil {
+		return kerror.New(kerror.ENil, "nil profile cannot write trace")
+	}
+	roots := p.Entries()
+	var origin time.Time
+	if len(roots) > 0 {
+		origin = roots[0].Start
+	}
+	var lanes []time.Time
+	events := []chromeTraceEvent{}
+	for _, root := range roots {
+		thread := -1
+		for i, end := range lanes {
+			if !end.After(root.Start) {
+				thread = i
+				break
+			}
+		}
+		if thread < 0 {
+			thread = len(lanes)
+			lanes = append(lanes, time.Time{})
+		}
+		lanes[thread] = root.end()
+		events = appendChromeTraceEvents(events, root, origin, thread+1)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(struct {
+		TraceEvents []chromeTraceEvent `json:"traceEvents"`
+	}{events})
+}
+
+// appendChromeTraceEvents appends events of the given entry and its children to given events.
+func appendChromeTraceEvents(events []chromeTraceEvent, entry *ProfileEntry, origin time.Time, thread int) []chromeTraceEvent {
+	event := chromeTraceEvent{
+		Name:      entry.subject(),
+		Category:  string(entry.Kind),
+		Phase:     "X",
+		Timestamp: float64(entry.Start.Sub(origin).Nanoseconds()) / 1e3,
+		Duration:  float64(entry.Duration.Nanoseconds()) / 1e3,
+		Process:   1,
+		Thread:    thread,
+	}
+	if entry.Err != nil {
+		event.Args = map[string]string{"error": entry.Err.Error()}
+	}
+	events = append(events, event)
+	for _, child := range entry.Children {
+		events = appendChromeTraceEvents(events, child, origin, thread)
+	}
+	return events
+}
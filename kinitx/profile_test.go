@@ -0,0 +1,170 @@
+package kinitx
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testProfiledConfig struct{}
+
+type testProfiledServer struct{}
+
+func newTestProfiledContainer(profile *Profile) *kinit.Container {
+	ctr := kinit.NewContainer()
+	ctr.SetTracer(profile)
+	ctr.MustProvide(MustNewConstructor(func() testProfiledConfig {
+		time.Sleep(20 * time.Millisecond)
+		return testProfiledConfig{}
+	}))
+	ctr.MustProvide(MustNewConstructor(func(testProfiledConfig) testProfiledServer {
+		time.Sleep(10 * time.Millisecond)
+		return testProfiledServer{}
+	}))
+	ctr.MustAttach(MustNewProcessor(func(testProfiledServer) {
+		time.Sleep(5 * time.Millisecond)
+	}))
+	return ctr
+}
+
+func TestProfile_Entries(t *testing.T) {
+	profile := NewProfile()
+	ctr := newTestProfiledContainer(profile)
+	ctr.MustRun(MustNewFunctor(func(testProfiledServer) {}))
+	roots := profile.Entries()
+	if len(roots) == 0 {
+		t.Fail()
+		return
+	}
+	server := roots[0]
+	if server.Kind != ConstructorTiming || server.Type != reflect.TypeOf(testProfiledServer{}) {
+		t.Logf("%+v", server)
+		t.Fail()
+		return
+	}
+	if len(server.Children) != 2 {
+		t.Logf("%+v", server.Children)
+		t.Fail()
+		return
+	}
+	config, proc := server.Children[0], server.Children[1]
+	if config.Type != reflect.TypeOf(testProfiledConfig{}) || proc.Kind != ProcessorTiming {
+		t.Fail()
+		return
+	}
+	if server.Duration < 35*time.Millisecond || server.Exclusive < 10*time.Millisecond ||
+		server.Exclusive >= server.Duration-config.Duration || config.Exclusive != config.Duration {
+		t.Logf("%s %s", server.Duration, server.Exclusive)
+		t.Fail()
+		return
+	}
+}
+
+func TestProfile_OnResolveEnd(t *testing.T) {
+	begun := time.Unix(0, 0)
+	now := begun
+	profile := NewProfile()
+	profile.now = func() time.Time { return now }
+	typ := reflect.TypeOf(testProfiledConfig{})
+	profile.OnResolveStart(typ)
+	// The reported duration is shorter than the observed one, e.g. when the hook is called late,
+	// while the processing ends after the creation has started by the container.
+	now = now.Add(20 * time.Millisecond)
+	profile.OnProcess(typ, nil, time.Millisecond, nil)
+	now = now.Add(time.Millisecond)
+	profile.OnResolveEnd(typ, nil, 5*time.Millisecond, nil)
+	roots := profile.Entries()
+	if len(roots) != 1 {
+		t.Logf("%+v", roots)
+		t.Fail()
+		return
+	}
+	if !roots[0].Start.Equal(begun) || len(roots[0].Children) != 1 {
+		t.Logf("%+v", roots[0])
+		t.Fail()
+		return
+	}
+}
+
+func TestProfile_WriteTree(t *testing.T) {
+	profile := NewProfile()
+	ctr := newTestProfiledContainer(profile)
+	ctr.MustRun(MustNewFunctor(func(testProfiledServer) {}))
+	var buf bytes.Buffer
+	if err := profile.WriteTree(&buf); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	t.Log(buf.String())
+	if !bytes.Contains(buf.Bytes(), []byte("  constructor  kinitx.testProfiledConfig by ")) {
+		t.Fail()
+		return
+	}
+}
+
+func TestProfile_WriteChromeTrace(t *testing.T) {
+	profile := NewProfile()
+	ctr := newTestProfiledContainer(profile)
+	ctr.SetParallel(true)
+	ctr.MustRun(
+		MustNewFunctor(func(testProfiledServer) {}),
+		MustNewFunctor(func(testProfiledConfig) {}),
+	)
+	var buf bytes.Buffer
+	if err := profile.WriteChromeTrace(&buf); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	t.Log(buf.String())
+	var trace struct {
+		TraceEvents []struct {
+			Name string  `json:"name"`
+			Cat  string  `json:"cat"`
+			Ph   string  `json:"ph"`
+			Ts   float64 `json:"ts"`
+			Dur  float64 `json:"dur"`
+			Tid  int     `json:"tid"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	// 2 constructors, a processor, 2 functors and 2 destructors.
+	if len(trace.TraceEvents) != 7 {
+		t.Fail()
+		return
+	}
+	for _, event := range trace.TraceEvents {
+		if event.Ph != "X" || event.Ts < 0 || event.Tid < 1 {
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestNilProfile_WriteTree(t *testing.T) {
+	err := (*Profile)(nil).WriteTree(&bytes.Buffer{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilProfile_WriteChromeTrace(t *testing.T) {
+	err := (*Profile)(nil).WriteChromeTrace(&bytes.Buffer{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
@@ -0,0 +1,87 @@
+package kinitx
+
+import (
+	"reflect"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// ScopedConstructor represents a constructor that creates objects of the certain scope (see the kinit.Scope).
+type ScopedConstructor struct {
+	// scope specifies the scope of objects created by this constructor.
+	scope kinit.Scope
+	// ctor specifies the underlying constructor.
+	ctor kinit.Constructor
+}
+
+// NewScopedConstructor returns a new scoped constructor.
+//
+// The scope must not be empty. See the documentation for the Provide
+// to find out possible values of the argument x.
+func NewScopedConstructor(scope kinit.Scope, x interface{}) (*ScopedConstructor, error) {
+	if scope == "" {
+		return nil, kerror.New(kerror.EViolation, "scope expected, empty string given")
+	}
+	ctor, err := castToConstructor(x)
+	if err != nil {
+		return nil, err
+	}
+	if ctor.Type() == nil {
+		return nil, kerror.New(kerror.EInvalid, "constructor for nil type cannot be scoped")
+	}
+	return &ScopedConstructor{
+		scope: scope,
+		ctor:  ctor,
+	}, nil
+}
+
+// MustNewScopedConstructor is a variant of the NewScopedConstructor that panics on error.
+func MustNewScopedConstructor(scope kinit.Scope, x interface{}) *ScopedConstructor {
+	c, err := NewScopedConstructor(scope, x)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Type implements the kinit.Constructor interface.
+func (c *ScopedConstructor) Type() reflect.Type {
+	if c == nil {
+		return nil
+	}
+	return c.ctor.Type()
+}
+
+// Parameters implements the kinit.Constructor interface.
+func (c *ScopedConstructor) Parameters() []reflect.Type {
+	if c == nil {
+		return nil
+	}
+	return c.ctor.Parameters()
+}
+
+// Create implements the kinit.Constructor interface.
+func (c *ScopedConstructor) Create(a ...reflect.Value) (reflect.Value, kdone.Destructor, error) {
+	if c == nil {
+		return reflect.Value{}, kdone.Noop, nil
+	}
+	return c.ctor.Create(a...)
+}
+
+// Scope implements the kinit.Scoper interface.
+func (c *ScopedConstructor) Scope() kinit.Scope {
+	if c == nil {
+		return ""
+	}
+	return c.scope
+}
+
+// Describe implements the kinit.Describer interface.
+func (c *ScopedConstructor) Describe() kinit.Description {
+	if c == nil {
+		return kinit.Description{}
+	}
+	return kinit.Describe(c.ctor)
+}
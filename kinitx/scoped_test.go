@@ -0,0 +1,108 @@
+package kinitx
+
+import (
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testScopedPool struct {
+	created int
+}
+
+type testScopedSession struct {
+	pool      *testScopedPool
+	destroyed bool
+}
+
+func (s *testScopedSession) Close() error {
+	s.destroyed = true
+	return nil
+}
+
+func TestScopedConstructor(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewScopedConstructor(kinit.Singleton, func() *testScopedPool {
+		return &testScopedPool{}
+	}))
+	ctr.MustProvide(MustNewScopedConstructor("request", func(pool *testScopedPool) *testScopedSession {
+		pool.created++
+		return &testScopedSession{pool: pool}
+	}))
+	var sessions []*testScopedSession
+	handle := MustNewFunctor(func(session *testScopedSession) {
+		sessions = append(sessions, session)
+	})
+	ctr.MustRun(MustNewFunctor(func(rt *kinit.Runtime) error {
+		for i := 0; i < 2; i++ {
+			if err := rt.RunScoped("request", handle, handle); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	if len(sessions) != 4 || sessions[0] != sessions[1] || sessions[1] == sessions[2] ||
+		sessions[0].pool != sessions[2].pool || sessions[0].pool.created != 2 {
+		t.Fail()
+		return
+	}
+	for _, s := range sessions {
+		if !s.destroyed {
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestScopedConstructor_Scope(t *testing.T) {
+	ctor := MustNewScopedConstructor("request", func() *testScopedSession { return nil })
+	if kinit.ScopeOf(ctor) != "request" {
+		t.Fail()
+		return
+	}
+	if kinit.ScopeOf(MustNewNamedConstructor("primary", ctor)) != "request" {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewScopedConstructor__EmptyScope(t *testing.T) {
+	_, err := NewScopedConstructor("", func() *testScopedSession { return nil })
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewScopedConstructor__Nil(t *testing.T) {
+	_, err := NewScopedConstructor("request", nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilScopedConstructor_Scope(t *testing.T) {
+	if (*ScopedConstructor)(nil).Scope() != "" {
+		t.Fail()
+		return
+	}
+}
+
+func TestScopedConstructor__Transient(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewScopedConstructor(kinit.Transient, func() *testScopedSession {
+		return &testScopedSession{}
+	}))
+	var sessions []*testScopedSession
+	ctr.MustRun(MustNewFunctor(func(s1, s2 *testScopedSession) {
+		sessions = append(sessions, s1, s2)
+	}))
+	if len(sessions) != 2 || sessions[0] == sessions[1] || !sessions[0].destroyed || !sessions[1].destroyed {
+		t.Fail()
+		return
+	}
+}
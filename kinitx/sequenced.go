@@ -0,0 +1,103 @@
+package kinitx
+
+import (
+	"reflect"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// SequencedProcessor represents a processor that must be called in the certain order
+// relative to other processors of the same type.
+type SequencedProcessor struct {
+	// label specifies the label other processors may refer to this processor by.
+	label string
+	// after specifies labels of processors that must be called before this processor.
+	after []string
+	// proc specifies the underlying processor.
+	proc kinit.Processor
+}
+
+// NewSequencedProcessor returns a new sequenced processor.
+//
+// The label may be empty if no other processors refer to this one. See the documentation
+// for the Attach to find out possible values of the argument x.
+func NewSequencedProcessor(label string, after []string, x interface{}) (*SequencedProcessor, error) {
+	proc, err := castToProcessor(x)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range after {
+		if l == "" {
+			return nil, kerror.New(kerror.EViolation, "label expected, empty string given")
+		}
+	}
+	p := &SequencedProcessor{
+		label: label,
+		proc:  proc,
+	}
+	if len(after) > 0 {
+		p.after = make([]string, len(after))
+		copy(p.after, after)
+	}
+	return p, nil
+}
+
+// MustNewSequencedProcessor is a variant of the NewSequencedProcessor that panics on error.
+func MustNewSequencedProcessor(label string, after []string, x interface{}) *SequencedProcessor {
+	p, err := NewSequencedProcessor(label, after, x)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Type implements the kinit.Processor interface.
+func (p *SequencedProcessor) Type() reflect.Type {
+	if p == nil {
+		return nil
+	}
+	return p.proc.Type()
+}
+
+// Parameters implements the kinit.Processor interface.
+func (p *SequencedProcessor) Parameters() []reflect.Type {
+	if p == nil {
+		return nil
+	}
+	return p.proc.Parameters()
+}
+
+// Process implements the kinit.Processor interface.
+func (p *SequencedProcessor) Process(obj reflect.Value, a ...reflect.Value) error {
+	if p == nil {
+		return nil
+	}
+	return p.proc.Process(obj, a...)
+}
+
+// Label implements the kinit.Sequencer interface.
+func (p *SequencedProcessor) Label() string {
+	if p == nil {
+		return ""
+	}
+	return p.label
+}
+
+// After implements the kinit.Sequencer interface.
+func (p *SequencedProcessor) After() []string {
+	if p == nil {
+		return nil
+	}
+	labels := make([]string, len(p.after))
+	copy(labels, p.after)
+	return labels
+}
+
+// Describe implements the kinit.Describer interface.
+func (p *SequencedProcessor) Describe() kinit.Description {
+	if p == nil {
+		return kinit.Description{}
+	}
+	return kinit.Describe(p.proc)
+}
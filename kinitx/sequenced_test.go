@@ -0,0 +1,64 @@
+package kinitx
+
+import (
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testSequencedT struct {
+	calls []string
+}
+
+func TestSequencedProcessor(t *testing.T) {
+	ctr := kinit.NewContainer()
+	ctr.MustProvide(MustNewConstructor(func() *testSequencedT { return &testSequencedT{} }))
+	ctr.MustAttach(MustNewSequencedProcessor("loops", []string{"metrics"}, func(obj *testSequencedT) {
+		obj.calls = append(obj.calls, "loops")
+	}))
+	ctr.MustAttach(MustNewSequencedProcessor("metrics", []string{"config"}, func(obj *testSequencedT) {
+		obj.calls = append(obj.calls, "metrics")
+	}))
+	ctr.MustAttach(MustNewSequencedProcessor("config", nil, func(obj *testSequencedT) {
+		obj.calls = append(obj.calls, "config")
+	}))
+	ctr.MustRun(MustNewFunctor(func(obj *testSequencedT) error {
+		if len(obj.calls) != 3 || obj.calls[0] != "config" || obj.calls[1] != "metrics" || obj.calls[2] != "loops" {
+			return kerror.Newf(kerror.EInvalid, "[config metrics loops] expected, %v given", obj.calls)
+		}
+		return nil
+	}))
+}
+
+func TestNewSequencedProcessor__Nil(t *testing.T) {
+	_, err := NewSequencedProcessor("config", nil, nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewSequencedProcessor__EmptyLabel(t *testing.T) {
+	_, err := NewSequencedProcessor("metrics", []string{""}, func(*testSequencedT) {})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilSequencedProcessor_Label(t *testing.T) {
+	if (*SequencedProcessor)(nil).Label() != "" {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilSequencedProcessor_After(t *testing.T) {
+	if (*SequencedProcessor)(nil).After() != nil {
+		t.Fail()
+		return
+	}
+}
@@ -0,0 +1,97 @@
+package kinitx
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// tagKey specifies the key of struct field tags that control the dependency injection.
+const tagKey = "kinit"
+
+// fieldTag represents a parsed struct field tag.
+type fieldTag struct {
+	// skip specifies whether the field must not be injected.
+	skip bool
+	// optional specifies whether the field dependency is optional.
+	optional bool
+	// group specifies whether the field must be injected with a group (plain or keyed).
+	group bool
+	// flatten specifies whether fields of the embedded struct must be injected instead of the field itself.
+	flatten bool
+	// name specifies the name the field dependency type is qualified by.
+	name string
+}
+
+// parseFieldTag parses the tag of the given struct field.
+//
+// The tag value is a comma separated list of options. Following options are supported:
+//
+//     - skips the field (must be the only option);
+//     optional makes the field dependency optional (see the kinit.Optional);
+//     name=N qualifies the field dependency type by the name N (see the kinit.Named);
+//     group=N injects the field of a slice or a string keyed map type with the group (see the kinit.Group
+//     and the kinit.KeyedGroup) of members creating objects of the element type qualified by the name N;
+//     flatten injects fields of the embedded struct (or struct pointer) instead of the field itself
+//     (must be the only option).
+//
+func parseFieldTag(sf reflect.StructField) (*fieldTag, error) {
+	tag := &fieldTag{}
+	value, ok := sf.Tag.Lookup(tagKey)
+	if !ok || value == "" {
+		return tag, nil
+	}
+	switch value {
+	case "-":
+		tag.skip = true
+		return tag, nil
+	case "flatten":
+		tag.flatten = true
+		return tag, nil
+	}
+	var named bool
+	for _, option := range strings.Split(value, ",") {
+		key, arg := option, ""
+		if i := strings.IndexByte(option, '='); i >= 0 {
+			key, arg = option[:i], option[i+1:]
+		}
+		switch key {
+		default:
+			return nil, kerror.Newf(kerror.EViolation, "field %s has unknown tag option %q", sf.Name, option)
+		case "optional":
+			if arg != "" {
+				return nil, kerror.Newf(kerror.EViolation, "field %s has unexpected argument of optional in tag", sf.Name)
+			}
+			tag.optional = true
+		case "name":
+			if arg == "" {
+				return nil, kerror.Newf(kerror.EViolation, "field %s has empty name in tag", sf.Name)
+			}
+			named = true
+			tag.name = arg
+		case "group":
+			t := sf.Type
+			if t.Kind() != reflect.Slice && (t.Kind() != reflect.Map || t.Key() != reflect.TypeOf("")) {
+				return nil, kerror.Newf(kerror.EViolation,
+					"field %s of %s type cannot be injected with group", sf.Name, t)
+			}
+			tag.group = true
+			tag.name = arg
+		}
+	}
+	if named && tag.group {
+		return nil, kerror.Newf(kerror.EViolation, "field %s has both name and group in tag", sf.Name)
+	}
+	return tag, nil
+}
+
+// dependencyType returns the type of a dependency the given struct field must be injected with.
+func (tag *fieldTag) dependencyType(sf reflect.StructField) reflect.Type {
+	t := kinit.Named(sf.Type, tag.name)
+	if tag.optional {
+		t = kinit.Optional(t)
+	}
+	return t
+}
@@ -0,0 +1,227 @@
+package kinitx
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+// StructuredLogger represents a structured logger like the *slog.Logger.
+//
+// Arguments following the message are alternating keys and values.
+type StructuredLogger interface {
+	// Debug logs the given message at the debug level.
+	Debug(msg string, args ...interface{})
+	// Error logs the given message at the error level.
+	Error(msg string, args ...interface{})
+}
+
+// LogTracer represents a tracer that logs events using a structured logger.
+//
+// Successful events are logged at the debug level and failures are logged at the error level.
+type LogTracer struct {
+	// logger specifies the logger to log events.
+	logger StructuredLogger
+}
+
+// NewLogTracer returns a new log tracer.
+//
+// The argument logger must not be nil.
+func NewLogTracer(logger StructuredLogger) (*LogTracer, error) {
+	if logger == nil {
+		return nil, kerror.New(kerror.EViolation, "logger expected, nil given")
+	}
+	return &LogTracer{
+		logger: logger,
+	}, nil
+}
+
+// MustNewLogTracer is a variant of the NewLogTracer that panics on error.
+func MustNewLogTracer(logger StructuredLogger) *LogTracer {
+	t, err := NewLogTracer(logger)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// OnResolveStart implements the kinit.Tracer interface.
+func (t *LogTracer) OnResolveStart(typ reflect.Type) {
+	if t == nil {
+		return
+	}
+	t.logger.Debug("creating object", "type", typ.String())
+}
+
+// OnResolveEnd implements the kinit.Tracer interface.
+func (t *LogTracer) OnResolveEnd(typ reflect.Type, ctor kinit.Constructor, d time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	t.log(err, "object created", "object creation failed",
+		"type", typ.String(), "constructor", kinit.Describe(ctor).String(), "duration", d)
+}
+
+// OnProcess implements the kinit.Tracer interface.
+func (t *LogTracer) OnProcess(typ reflect.Type, proc kinit.Processor, d time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	t.log(err, "object processed", "object processing failed",
+		"type", typ.String(), "processor", kinit.Describe(proc).String(), "duration", d)
+}
+
+// OnDestroy implements the kinit.Tracer interface.
+func (t *LogTracer) OnDestroy(typ reflect.Type, d time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	t.log(err, "object destroyed", "object destruction failed",
+		"type", typ.String(), "duration", d)
+}
+
+// OnFunctorCall implements the kinit.Tracer interface.
+func (t *LogTracer) OnFunctorCall(fun kinit.Functor, d time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	t.log(err, "functor called", "functor call failed",
+		"functor", kinit.Describe(fun).String(), "duration", d)
+}
+
+// log logs the success message at the debug level or the failure message
+// at the error level depending on the given error.
+func (t *LogTracer) log(err error, success, failure string, args ...interface{}) {
+	if err != nil {
+		t.logger.Error(failure, append(args, "error", err)...)
+		return
+	}
+	t.logger.Debug(success, args...)
+}
+
+// TimingKind represents a kind of a timing.
+type TimingKind string
+
+const (
+	// ConstructorTiming specifies the kind of timings of object creations.
+	ConstructorTiming TimingKind = "constructor"
+	// ProcessorTiming specifies the kind of timings of object processings.
+	ProcessorTiming TimingKind = "processor"
+	// DestructorTiming specifies the kind of timings of object destructions.
+	DestructorTiming TimingKind = "destructor"
+	// FunctorTiming specifies the kind of timings of functor calls.
+	FunctorTiming TimingKind = "functor"
+)
+
+// Timing represents the duration of a traced event.
+type Timing struct {
+	// Kind specifies the kind of this timing.
+	Kind TimingKind
+	// Type specifies the type of an object (nil for functors).
+	Type reflect.Type
+	// Description specifies the description of a constructor, processor or functor.
+	Description kinit.Description
+	// Duration specifies the duration of an event.
+	//
+	// Durations of object creations and processings include the resolution of dependencies.
+	Duration time.Duration
+	// Err specifies the error occurred.
+	Err error
+}
+
+// subject returns the human-readable subject of this timing.
+func (t Timing) subject() string {
+	subject := t.Description.Name
+	if t.Type != nil {
+		subject = t.Type.String()
+		if t.Description.Name != "" {
+			subject += " by " + t.Description.Name
+		}
+	}
+	if t.Err != nil {
+		subject += " (failed)"
+	}
+	return subject
+}
+
+// TimingTracer represents a tracer that records durations of events to build a timing report.
+type TimingTracer struct {
+	// mutex specifies the mutex that guards fields below.
+	mutex sync.Mutex
+	// timings specifies recorded timings.
+	timings []Timing
+}
+
+// NewTimingTracer returns a new timing tracer.
+func NewTimingTracer() *TimingTracer {
+	return &TimingTracer{}
+}
+
+// OnResolveStart implements the kinit.Tracer interface.
+func (t *TimingTracer) OnResolveStart(typ reflect.Type) {}
+
+// OnResolveEnd implements the kinit.Tracer interface.
+func (t *TimingTracer) OnResolveEnd(typ reflect.Type, ctor kinit.Constructor, d time.Duration, err error) {
+	t.record(Timing{ConstructorTiming, typ, kinit.Describe(ctor), d, err})
+}
+
+// OnProcess implements the kinit.Tracer interface.
+func (t *TimingTracer) OnProcess(typ reflect.Type, proc kinit.Processor, d time.Duration, err error) {
+	t.record(Timing{ProcessorTiming, typ, kinit.Describe(proc), d, err})
+}
+
+// OnDestroy implements the kinit.Tracer interface.
+func (t *TimingTracer) OnDestroy(typ reflect.Type, d time.Duration, err error) {
+	t.record(Timing{DestructorTiming, typ, kinit.Description{}, d, err})
+}
+
+// OnFunctorCall implements the kinit.Tracer interface.
+func (t *TimingTracer) OnFunctorCall(fun kinit.Functor, d time.Duration, err error) {
+	t.record(Timing{FunctorTiming, nil, kinit.Describe(fun), d, err})
+}
+
+// record records the given timing.
+func (t *TimingTracer) record(timing Timing) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.timings = append(t.timings, timing)
+}
+
+// Timings returns recorded timings sorted by duration in the descending order.
+func (t *TimingTracer) Timings() []Timing {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	timings := make([]Timing, len(t.timings))
+	copy(timings, t.timings)
+	t.mutex.Unlock()
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Duration > timings[j].Duration
+	})
+	return timings
+}
+
+// WriteReport writes the timing report to the given writer.
+//
+// Each line of the report contains the duration, the kind and the subject of an event.
+func (t *TimingTracer) WriteReport(w io.Writer) error {
+	if t == nil {
+		return kerror.New(kerror.ENil, "nil timing tracer cannot write report")
+	}
+	for _, timing := range t.Timings() {
+		if _, err := fmt.Fprintf(w, "%12s  %-11s  %s\n", timing.Duration, timing.Kind, timing.subject()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
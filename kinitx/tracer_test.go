@@ -0,0 +1,117 @@
+package kinitx
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-kata/kerror"
+	"github.com/go-kata/kinit"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Debug(msg string, args ...interface{}) {
+	l.lines = append(l.lines, "DEBUG "+msg+" "+fmt.Sprint(args...))
+}
+
+func (l *testLogger) Error(msg string, args ...interface{}) {
+	l.lines = append(l.lines, "ERROR "+msg+" "+fmt.Sprint(args...))
+}
+
+func TestLogTracer(t *testing.T) {
+	logger := &testLogger{}
+	ctr := kinit.NewContainer()
+	ctr.SetTracer(MustNewLogTracer(logger))
+	ctr.MustProvide(MustNewConstructor(func() int { return 1 }))
+	ctr.MustAttach(MustNewProcessor(func(int) {}))
+	err := ctr.Run(MustNewFunctor(func(int) error {
+		return kerror.New(kerror.ERuntime, "test error")
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ERuntime {
+		t.Fail()
+		return
+	}
+	expected := []string{
+		"DEBUG creating object",
+		"DEBUG object processed",
+		"DEBUG object created",
+		"ERROR functor call failed",
+		"DEBUG object destroyed",
+	}
+	if len(logger.lines) != len(expected) {
+		t.Logf("%v", logger.lines)
+		t.Fail()
+		return
+	}
+	for i, line := range logger.lines {
+		t.Log(line)
+		if !strings.HasPrefix(line, expected[i]) {
+			t.Fail()
+			return
+		}
+	}
+	if !strings.Contains(logger.lines[3], "test error") {
+		t.Fail()
+		return
+	}
+}
+
+func TestNewLogTracer__NilLogger(t *testing.T) {
+	_, err := NewLogTracer(nil)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EViolation {
+		t.Fail()
+		return
+	}
+}
+
+func TestTimingTracer(t *testing.T) {
+	tracer := NewTimingTracer()
+	ctr := kinit.NewContainer()
+	ctr.SetTracer(tracer)
+	ctr.MustProvide(MustNewConstructor(func() int { return 1 }))
+	ctr.MustProvide(MustNewConstructor(func(int) string { return "" }))
+	ctr.MustAttach(MustNewProcessor(func(string) {}))
+	ctr.MustRun(MustNewFunctor(func(string) {}))
+	kinds := make(map[TimingKind]int)
+	for _, timing := range tracer.Timings() {
+		kinds[timing.Kind]++
+	}
+	if kinds[ConstructorTiming] != 2 || kinds[ProcessorTiming] != 1 || kinds[FunctorTiming] != 1 {
+		t.Logf("%v", kinds)
+		t.Fail()
+		return
+	}
+	timings := tracer.Timings()
+	for i := 1; i < len(timings); i++ {
+		if timings[i].Duration > timings[i-1].Duration {
+			t.Fail()
+			return
+		}
+	}
+	var buf bytes.Buffer
+	if err := tracer.WriteReport(&buf); err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	t.Log(buf.String())
+	if strings.Count(buf.String(), "\n") != len(timings) || !strings.Contains(buf.String(), "string by ") {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilTimingTracer_WriteReport(t *testing.T) {
+	err := (*TimingTracer)(nil).WriteReport(&bytes.Buffer{})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
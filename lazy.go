@@ -0,0 +1,65 @@
+package kinit
+
+import "reflect"
+
+// errorType specifies the reflection to the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Lazy returns the type of the lazy dependency on objects of the given type.
+//
+// The lazy dependency on objects of the type T has the func() (T, error) type. Instead of an object
+// the container injects a function that resolves it on the first call using the arena of the current run
+// (objects of the Transient scope are created on each call). Thus lazy dependencies allow to skip the creation of objects a code path never uses and to break
+// dependency cycles. If the given type is qualified by a name, the lazy type will be qualified by the same name.
+//
+// A lazy dependency must not be called by constructors of objects it depends on:
+// the container will report a cyclic dependency in this case.
+func Lazy(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	return Named(reflect.FuncOf(nil, []reflect.Type{Actual(t), errorType}, false), NameOf(t))
+}
+
+// LazyTarget returns the type of objects the given lazy type resolves to.
+//
+// Any unnamed type of the func() (T, error) form is considered lazy unless a constructor is registered for it.
+// Named function types (e.g. user-defined factories) are never considered lazy.
+// If the given type is qualified by a name, the returned type will be qualified by the same name.
+// Nil will be returned if the given type isn't lazy.
+func LazyTarget(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	ft := Actual(t)
+	if ft.Kind() != reflect.Func || ft.Name() != "" || ft.NumIn() != 0 || ft.IsVariadic() ||
+		ft.NumOut() != 2 || ft.Out(1) != errorType {
+		return nil
+	}
+	return Named(ft.Out(0), NameOf(t))
+}
+
+// isLazy returns boolean specifies whether the given type must be resolved lazily by the given container.
+func (c *Container) isLazy(t reflect.Type) bool {
+	if LazyTarget(t) == nil {
+		return false
+	}
+	ctor, _ := c.Lookup(t)
+	return ctor == nil && !c.HasGroup(t)
+}
+
+// resolveLazy returns the function that resolves the object of the type the given lazy type resolves to
+// using the given resolution branch on each call. The object is created on the first call and subsequent
+// calls return it from the arena, except for objects of the Transient scope which are created on each call.
+func (c *Container) resolveLazy(res *resolution, t reflect.Type) reflect.Value {
+	target := LazyTarget(t)
+	return reflect.MakeFunc(Actual(t), func([]reflect.Value) []reflect.Value {
+		out := reflect.New(Actual(target)).Elem()
+		obj, err := c.resolveType(res, target)
+		if err != nil {
+			return []reflect.Value{out, reflect.ValueOf(&err).Elem()}
+		}
+		out.Set(obj)
+		return []reflect.Value{out, reflect.Zero(errorType)}
+	})
+}
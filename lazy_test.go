@@ -0,0 +1,157 @@
+package kinit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+func TestLazy(t *testing.T) {
+	it := reflect.TypeOf(0)
+	lt := reflect.TypeOf((func() (int, error))(nil))
+	if Lazy(it) != lt || LazyTarget(lt) != it {
+		t.Fail()
+		return
+	}
+	if Lazy(Named(it, "primary")) != Named(lt, "primary") || LazyTarget(Named(lt, "primary")) != Named(it, "primary") {
+		t.Fail()
+		return
+	}
+	if LazyTarget(it) != nil || LazyTarget(reflect.TypeOf((func() int)(nil))) != nil {
+		t.Fail()
+		return
+	}
+	if LazyTarget(reflect.TypeOf((testLazyFactory)(nil))) != nil {
+		t.Fail()
+		return
+	}
+	if Lazy(nil) != nil || LazyTarget(nil) != nil {
+		t.Fail()
+		return
+	}
+}
+
+type testLazyObject1 struct {
+	object2 func() (*testLazyObject2, error)
+}
+
+type testLazyObject2 struct {
+	object1 *testLazyObject1
+}
+
+func newTestLazyContainer(created *int) *Container {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func(object2 func() (*testLazyObject2, error)) (*testLazyObject1, kdone.Destructor, error) {
+		return &testLazyObject1{object2}, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(object1 *testLazyObject1) (*testLazyObject2, kdone.Destructor, error) {
+		*created++
+		return &testLazyObject2{object1}, kdone.Noop, nil
+	}))
+	return ctr
+}
+
+func TestContainer_Run__Lazy(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		var created int
+		ctr := newTestLazyContainer(&created)
+		ctr.SetParallel(parallel)
+		err := ctr.Run(newTestFunctor(func(object1 *testLazyObject1) ([]Functor, error) {
+			if created != 0 {
+				t.Fail()
+			}
+			object2, err := object1.object2()
+			if err != nil {
+				return nil, err
+			}
+			if object2.object1 != object1 {
+				t.Fail()
+			}
+			if again, _ := object1.object2(); again != object2 || created != 1 {
+				t.Fail()
+			}
+			return nil, nil
+		}))
+		if err != nil {
+			t.Logf("%+v", err)
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestContainer_Run__LazyNotCalled(t *testing.T) {
+	var created int
+	ctr := newTestLazyContainer(&created)
+	ctr.MustRun(newTestFunctor(func(object1 *testLazyObject1) ([]Functor, error) {
+		return nil, nil
+	}))
+	if created != 0 {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__LazyCalledByDependency(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func(object2 func() (*testLazyObject2, error)) (*testLazyObject1, kdone.Destructor, error) {
+		if _, err := object2(); err != nil {
+			return nil, nil, err
+		}
+		return &testLazyObject1{object2}, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(object1 *testLazyObject1) (*testLazyObject2, kdone.Destructor, error) {
+		return &testLazyObject2{object1}, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(*testLazyObject1) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__LazyNotFound(t *testing.T) {
+	ctr := NewContainer()
+	err := ctr.Run(newTestFunctor(func(lazy func() (int, error)) ([]Functor, error) {
+		_, err := lazy()
+		return nil, err
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
+type testLazyFactory func() (int, error)
+
+func TestContainer_Run__NamedFuncType(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (testLazyFactory, kdone.Destructor, error) {
+		return func() (int, error) { return 42, nil }, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(factory testLazyFactory) ([]Functor, error) {
+		if i, err := factory(); err != nil || i != 42 {
+			return nil, kerror.Newf(kerror.EInvalid, "factory: %d expected, %d given", 42, i)
+		}
+		return nil, nil
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	err = NewContainer().Run(newTestFunctor(func(testLazyFactory) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
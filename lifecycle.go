@@ -0,0 +1,97 @@
+package kinit
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+// Starter represents an object that must be started after its creation.
+//
+// Objects implementing this interface are started in the order of their creation, so that any object
+// is started after objects it depends on. The context of the run which arena the object is registered on
+// is passed to the Start method, thus it is the same context the object may depend on.
+// If the start fails, the object is destroyed instead of being registered on the arena.
+type Starter interface {
+	// Start starts this object.
+	Start(ctx context.Context) error
+}
+
+// Stopper represents an object that must be stopped before its destruction.
+//
+// Objects implementing this interface are stopped in the reverse order of their creation,
+// so that any object is stopped before objects it depends on. All objects are stopped before
+// the destruction of any object registered on the same arena.
+type Stopper interface {
+	// Stop stops this object.
+	//
+	// The passed context is done when the stop timeout elapses.
+	Stop(ctx context.Context) error
+}
+
+// start starts the given object if it implements the Starter interface and enlists it
+// for stopping on the given arena if it implements the Stopper interface.
+//
+// Objects referred by pointers are started only once per arena chain, thus objects
+// passed through by other constructors (e.g. bound to interfaces) are not started again.
+func start(ctx context.Context, arena *Arena, obj reflect.Value) error {
+	if !obj.IsValid() || !obj.CanInterface() {
+		return nil
+	}
+	x := obj.Interface()
+	starter, isStarter := x.(Starter)
+	stopper, isStopper := x.(Stopper)
+	if !isStarter && !isStopper {
+		return nil
+	}
+	if !arena.track(x) {
+		return nil
+	}
+	if isStarter {
+		if err := starter.Start(ctx); err != nil {
+			arena.untrack(x)
+			return err
+		}
+	}
+	if isStopper {
+		return arena.enlist(stopper)
+	}
+	return nil
+}
+
+// discard destroys the object which creation failed with the given error
+// using the given destructor and returns the error joined with the destruction one.
+func discard(err error, dtor kdone.Destructor) error {
+	if dtor == nil {
+		return err
+	}
+	return kerror.Join(err, dtor.Destroy())
+}
+
+// stop stops the given object waiting for it no longer than the given timeout.
+//
+// The zero timeout means no time limit.
+func stop(stopper Stopper, timeout time.Duration) error {
+	if timeout <= 0 {
+		return kerror.Try(func() error {
+			return stopper.Stop(context.Background())
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- kerror.Try(func() error {
+			return stopper.Stop(ctx)
+		})
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return kerror.Newf(kerror.ERuntime, "%T object was not stopped in %s", stopper, timeout)
+	}
+}
@@ -0,0 +1,251 @@
+package kinit
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+type testService struct {
+	name  string
+	log   *[]string
+	err   error
+	delay time.Duration
+}
+
+func (s *testService) Start(ctx context.Context) error {
+	*s.log = append(*s.log, "start "+s.name)
+	return s.err
+}
+
+func (s *testService) Stop(ctx context.Context) error {
+	time.Sleep(s.delay)
+	*s.log = append(*s.log, "stop "+s.name)
+	return nil
+}
+
+func (s *testService) destructor() kdone.Destructor {
+	return kdone.DestructorFunc(func() error {
+		*s.log = append(*s.log, "destroy "+s.name)
+		return nil
+	})
+}
+
+type testDatabase struct {
+	*testService
+}
+
+type testServer struct {
+	*testService
+}
+
+func TestContainer_Run__Lifecycle(t *testing.T) {
+	var log []string
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (testDatabase, kdone.Destructor, error) {
+		db := testDatabase{&testService{name: "database", log: &log}}
+		return db, db.destructor(), nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(testDatabase) (testServer, kdone.Destructor, error) {
+		srv := testServer{&testService{name: "server", log: &log}}
+		return srv, srv.destructor(), nil
+	}))
+	ctr.MustRun(newTestFunctor(func(testServer) ([]Functor, error) {
+		log = append(log, "serve")
+		return nil, nil
+	}))
+	expected := []string{
+		"start database", "start server", "serve",
+		"stop server", "stop database", "destroy server", "destroy database",
+	}
+	t.Logf("%v", log)
+	if len(log) != len(expected) {
+		t.Fail()
+		return
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestContainer_Run__LifecycleGroup(t *testing.T) {
+	var log []string
+	ctr := NewContainer()
+	ctr.MustContribute(newTestConstructor(func() (*testService, kdone.Destructor, error) {
+		return &testService{name: "first", log: &log}, kdone.Noop, nil
+	}))
+	ctr.MustContribute(newTestConstructor(func() (*testService, kdone.Destructor, error) {
+		return &testService{name: "second", log: &log}, kdone.Noop, nil
+	}))
+	ctr.MustRun(newTestFunctor(func([]*testService) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%v", log)
+	if len(log) != 4 || log[0] != "start first" || log[3] != "stop first" {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__FailedStart(t *testing.T) {
+	var log []string
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (*testService, kdone.Destructor, error) {
+		s := &testService{name: "service", log: &log, err: kerror.New(kerror.Label("test.Error"), "test error")}
+		return s, s.destructor(), nil
+	}))
+	err := ctr.Run(newTestFunctor(func(*testService) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.Label("test.Error") {
+		t.Fail()
+		return
+	}
+	t.Logf("%v", log)
+	if len(log) != 2 || log[0] != "start service" || log[1] != "destroy service" {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__RetryFailedStart(t *testing.T) {
+	var log []string
+	var created int
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (*testService, kdone.Destructor, error) {
+		created++
+		s := &testService{name: "service", log: &log}
+		if created == 1 {
+			s.err = kerror.New(kerror.Label("test.Error"), "test error")
+		}
+		return s, s.destructor(), nil
+	}))
+	err := ctr.Run(newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+		_, err := runtime.Resolve(reflect.TypeOf((*testService)(nil)))
+		t.Logf("%+v", err)
+		if kerror.ClassOf(err) != kerror.Label("test.Error") {
+			return nil, kerror.New(kerror.EInvalid, "failed start expected")
+		}
+		obj, err := runtime.Resolve(reflect.TypeOf((*testService)(nil)))
+		if err != nil {
+			return nil, err
+		}
+		if obj.Interface().(*testService).err != nil {
+			return nil, kerror.New(kerror.EInvalid, "object which start failed is resolved")
+		}
+		return nil, nil
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	expected := []string{"start service", "destroy service", "start service", "stop service", "destroy service"}
+	t.Logf("%v", log)
+	if len(log) != len(expected) {
+		t.Fail()
+		return
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestContainer_Run__StopTimeout(t *testing.T) {
+	ctr := NewContainer()
+	ctr.SetStopTimeout(10 * time.Millisecond)
+	if ctr.StopTimeout() != 10*time.Millisecond {
+		t.Fail()
+		return
+	}
+	var destroyed bool
+	ctr.MustProvide(newTestConstructor(func() (*testService, kdone.Destructor, error) {
+		return &testService{name: "service", log: &[]string{}, delay: time.Second}, kdone.DestructorFunc(func() error {
+			destroyed = true
+			return nil
+		}), nil
+	}))
+	err := ctr.Run(newTestFunctor(func(*testService) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ERuntime {
+		t.Fail()
+		return
+	}
+	if !destroyed {
+		t.Fail()
+		return
+	}
+}
+
+type testPanickingService struct{}
+
+func (testPanickingService) Stop(ctx context.Context) error {
+	panic("test panic")
+}
+
+func TestContainer_Run__PanickingStop(t *testing.T) {
+	ctr := NewContainer()
+	var destroyed bool
+	ctr.MustProvide(newTestConstructor(func() (testPanickingService, kdone.Destructor, error) {
+		return testPanickingService{}, kdone.DestructorFunc(func() error {
+			destroyed = true
+			return nil
+		}), nil
+	}))
+	err := ctr.Run(newTestFunctor(func(testPanickingService) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if err == nil || !destroyed {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__LifecyclePassThrough(t *testing.T) {
+	var log []string
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (*testService, kdone.Destructor, error) {
+		s := &testService{name: "service", log: &log}
+		return s, s.destructor(), nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(s *testService) (Stopper, kdone.Destructor, error) {
+		return s, kdone.Noop, nil
+	}))
+	ctr.MustRun(newTestFunctor(func(Stopper) ([]Functor, error) {
+		return nil, nil
+	}))
+	expected := []string{"start service", "stop service", "destroy service"}
+	t.Logf("%v", log)
+	if len(log) != len(expected) {
+		t.Fail()
+		return
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestNilContainer_SetStopTimeout(t *testing.T) {
+	(*Container)(nil).SetStopTimeout(time.Second)
+	if (*Container)(nil).StopTimeout() != 0 {
+		t.Fail()
+		return
+	}
+}
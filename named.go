@@ -0,0 +1,61 @@
+package kinit
+
+import "reflect"
+
+// namedType represents a type qualified by a name.
+type namedType struct {
+	// Type specifies the actual type.
+	reflect.Type
+	// name specifies the name qualifying the actual type.
+	name string
+}
+
+// String implements the fmt.Stringer interface.
+func (t namedType) String() string {
+	return t.Type.String() + "@" + t.name
+}
+
+// Named returns the given type qualified by the given name.
+//
+// Qualified types allow to register several constructors (as well as processors and objects)
+// for the same Go type, e.g. the primary and the replica database connection pools. Two qualified
+// types are equal only when they have equal actual types and equal names.
+//
+// If the given type is already qualified by a name it will be requalified.
+// The empty name means an unqualified type.
+func Named(t reflect.Type, name string) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	if nt, ok := t.(namedType); ok {
+		t = nt.Type
+	}
+	if name == "" {
+		return t
+	}
+	return namedType{
+		Type: t,
+		name: name,
+	}
+}
+
+// NameOf returns the name the given type is qualified by.
+//
+// The empty string will be returned for an unqualified type.
+func NameOf(t reflect.Type) string {
+	if nt, ok := t.(namedType); ok {
+		return nt.name
+	}
+	return ""
+}
+
+// Actual returns the actual Go type of the given (maybe qualified) type.
+//
+// Qualified types cannot be passed to reflect functions like reflect.New or reflect.Zero,
+// use this function to unwrap them before.
+func Actual(t reflect.Type) reflect.Type {
+	if nt, ok := t.(namedType); ok {
+		return nt.Type
+	}
+	return t
+}
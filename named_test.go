@@ -0,0 +1,86 @@
+package kinit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+func TestNamed(t *testing.T) {
+	it := reflect.TypeOf(0)
+	primary := Named(it, "primary")
+	replica := Named(it, "replica")
+	t.Logf("%s %s", primary, replica)
+	if primary == it || primary == replica || primary != Named(it, "primary") {
+		t.Fail()
+		return
+	}
+	if NameOf(primary) != "primary" || NameOf(replica) != "replica" || NameOf(it) != "" {
+		t.Fail()
+		return
+	}
+	if Actual(primary) != it || Actual(replica) != it || Actual(it) != it {
+		t.Fail()
+		return
+	}
+	if Named(primary, "replica") != replica || Named(primary, "") != it {
+		t.Fail()
+		return
+	}
+}
+
+func TestNamed__Nil(t *testing.T) {
+	if Named(nil, "primary") != nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer__Named(t *testing.T) {
+	it := reflect.TypeOf(0)
+	ctr := NewContainer()
+	ctr.MustProvide(testNamedConstructor{newTestConstructor(func() (int, kdone.Destructor, error) {
+		return 1, kdone.Noop, nil
+	}), "primary"})
+	ctr.MustProvide(testNamedConstructor{newTestConstructor(func() (int, kdone.Destructor, error) {
+		return 2, kdone.Noop, nil
+	}), "replica"})
+	if ctor, _ := ctr.Lookup(Named(it, "primary")); ctor == nil {
+		t.Fail()
+		return
+	}
+	if ctor, _ := ctr.Lookup(it); ctor != nil {
+		t.Fail()
+		return
+	}
+	ctr.MustRun(testNamedFunctor{func(a ...reflect.Value) ([]Functor, error) {
+		if a[0].Interface() != 1 || a[1].Interface() != 2 {
+			return nil, kerror.Newf(kerror.EInvalid, "1 and 2 expected, %v and %v given", a[0], a[1])
+		}
+		return nil, nil
+	}, []reflect.Type{Named(it, "primary"), Named(it, "replica")}})
+}
+
+type testNamedConstructor struct {
+	*testConstructor
+	name string
+}
+
+func (c testNamedConstructor) Type() reflect.Type {
+	return Named(c.testConstructor.Type(), c.name)
+}
+
+type testNamedFunctor struct {
+	f  func(a ...reflect.Value) ([]Functor, error)
+	in []reflect.Type
+}
+
+func (f testNamedFunctor) Parameters() []reflect.Type {
+	return f.in
+}
+
+func (f testNamedFunctor) Call(a ...reflect.Value) ([]Functor, error) {
+	return f.f(a...)
+}
@@ -0,0 +1,78 @@
+package kinit
+
+import "reflect"
+
+// boolType specifies the reflection to the bool type.
+var boolType = reflect.TypeOf(false)
+
+// Optional returns the type of the optional dependency on objects of the given type.
+//
+// The optional dependency on objects of the type T has the struct { Value T; Present bool } type.
+// If there is no constructor for the type T the container injects the zero value with the Present set to false
+// instead of failing the run. If the given type is qualified by a name, the optional type will be qualified
+// by the same name.
+func Optional(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	return Named(reflect.StructOf([]reflect.StructField{
+		{Name: "Value", Type: Actual(t)},
+		{Name: "Present", Type: boolType},
+	}), NameOf(t))
+}
+
+// OptionalTarget returns the type of objects the given optional type resolves to.
+//
+// Any unnamed type of the struct { Value T; Present bool } form is considered optional unless a constructor
+// is registered for it. Named struct types are never considered optional. If the given type is qualified by a name, the returned type will be qualified
+// by the same name. Nil will be returned if the given type isn't optional.
+func OptionalTarget(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	st := Actual(t)
+	if st.Kind() != reflect.Struct || st.Name() != "" || st.NumField() != 2 {
+		return nil
+	}
+	value, present := st.Field(0), st.Field(1)
+	if value.Name != "Value" || value.Tag != "" || present.Name != "Present" || present.Tag != "" ||
+		present.Type != boolType {
+		return nil
+	}
+	return Named(value.Type, NameOf(t))
+}
+
+// isOptional returns boolean specifies whether the given type must be resolved optionally by the given container.
+func (c *Container) isOptional(t reflect.Type) bool {
+	if OptionalTarget(t) == nil {
+		return false
+	}
+	ctor, _ := c.Lookup(t)
+	return ctor == nil && !c.HasGroup(t)
+}
+
+// provides returns boolean specifies whether an object of the given type
+// can be obtained from the given arena or created by this container.
+func (c *Container) provides(arena *Arena, t reflect.Type) bool {
+	if _, ok := arena.Get(t); ok {
+		return true
+	}
+	ctor, _ := c.Lookup(t)
+	return ctor != nil || c.HasGroup(t)
+}
+
+// resolveOptional returns the object of the given optional type using the given resolution branch.
+func (c *Container) resolveOptional(res *resolution, t reflect.Type) (reflect.Value, error) {
+	target := OptionalTarget(t)
+	opt := reflect.New(Actual(t)).Elem()
+	if !c.provides(res.arena, target) {
+		return opt, nil
+	}
+	obj, err := c.resolveType(res, target)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	opt.Field(0).Set(obj)
+	opt.Field(1).SetBool(true)
+	return opt, nil
+}
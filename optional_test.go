@@ -0,0 +1,116 @@
+package kinit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+func TestOptional(t *testing.T) {
+	it := reflect.TypeOf(0)
+	ot := reflect.TypeOf(struct {
+		Value   int
+		Present bool
+	}{})
+	if Optional(it) != ot || OptionalTarget(ot) != it {
+		t.Fail()
+		return
+	}
+	if Optional(Named(it, "primary")) != Named(ot, "primary") || OptionalTarget(Named(ot, "primary")) != Named(it, "primary") {
+		t.Fail()
+		return
+	}
+	if OptionalTarget(it) != nil || OptionalTarget(reflect.TypeOf(struct{ Value int }{})) != nil {
+		t.Fail()
+		return
+	}
+	if OptionalTarget(reflect.TypeOf(testOptionalResult{})) != nil {
+		t.Fail()
+		return
+	}
+	if Optional(nil) != nil || OptionalTarget(nil) != nil {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__Optional(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		ctr := NewContainer()
+		ctr.SetParallel(parallel)
+		ctr.MustProvide(newTestConstructor(func() (int16, kdone.Destructor, error) {
+			return 1, kdone.Noop, nil
+		}))
+		err := ctr.Run(newTestFunctor(func(
+			present struct {
+				Value   int16
+				Present bool
+			},
+			absent struct {
+				Value   int32
+				Present bool
+			},
+		) ([]Functor, error) {
+			if !present.Present || present.Value != 1 || absent.Present || absent.Value != 0 {
+				t.Fail()
+			}
+			return nil, nil
+		}))
+		if err != nil {
+			t.Logf("%+v", err)
+			t.Fail()
+			return
+		}
+	}
+}
+
+func TestContainer_Run__OptionalWithFailedConstructor(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (int16, kdone.Destructor, error) {
+		return 0, nil, kerror.New(kerror.ERuntime, "test error")
+	}))
+	err := ctr.Run(newTestFunctor(func(struct {
+		Value   int16
+		Present bool
+	}) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ERuntime {
+		t.Fail()
+		return
+	}
+}
+
+type testOptionalResult struct {
+	Value   int
+	Present bool
+}
+
+func TestContainer_Run__NamedStructType(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (testOptionalResult, kdone.Destructor, error) {
+		return testOptionalResult{Value: 42, Present: true}, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(result testOptionalResult) ([]Functor, error) {
+		if !result.Present || result.Value != 42 {
+			return nil, kerror.Newf(kerror.EInvalid, "result: %d expected, %d given", 42, result.Value)
+		}
+		return nil, nil
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	err = NewContainer().Run(newTestFunctor(func(testOptionalResult) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
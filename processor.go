@@ -1,6 +1,11 @@
 package kinit
 
-import "reflect"
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/go-kata/kerror"
+)
 
 // Processor represents an object processor.
 //
@@ -13,3 +18,96 @@ type Processor interface {
 	// Process processes the given object.
 	Process(obj reflect.Value, a ...reflect.Value) error
 }
+
+// Sequencer represents an optional interface of a processor that must be called
+// in the certain order relative to other processors of the same type.
+type Sequencer interface {
+	// Label returns the label other processors may refer to this processor by.
+	Label() string
+	// After returns labels of processors that must be called before this processor.
+	After() []string
+}
+
+// Sequence returns given processors of the same type ordered according to constraints
+// declared by ones implementing the Sequencer interface.
+//
+// Processors which order is not constrained keep the order they were given in.
+// References to absent labels are ignored. Multiple processors with the same label
+// and contradictory constraints are considered as errors.
+func Sequence(processors []Processor) ([]Processor, error) {
+	n := len(processors)
+	labels := make(map[string]int)
+	constrained := false
+	for i, proc := range processors {
+		seq, ok := proc.(Sequencer)
+		if !ok {
+			continue
+		}
+		constrained = true
+		label := seq.Label()
+		if label == "" {
+			continue
+		}
+		if j, ok := labels[label]; ok {
+			return nil, kerror.Newf(kerror.EAmbiguous, "%s processor labeled %q already registered%s",
+				proc.Type(), label, describe(processors[j]))
+		}
+		labels[label] = i
+	}
+	ordered := make([]Processor, 0, n)
+	if !constrained {
+		return append(ordered, processors...), nil
+	}
+	// predecessors[i] specifies the number of processors that must be called before the i-th one
+	// and successors[i] specifies indexes of processors that must be called after the i-th one.
+	predecessors := make([]int, n)
+	successors := make([][]int, n)
+	for i, proc := range processors {
+		seq, ok := proc.(Sequencer)
+		if !ok {
+			continue
+		}
+		for _, label := range seq.After() {
+			j, ok := labels[label]
+			if !ok {
+				continue
+			}
+			predecessors[i]++
+			successors[j] = append(successors[j], i)
+		}
+	}
+	placed := make([]bool, n)
+	for len(ordered) < n {
+		next := -1
+		for i := 0; i < n; i++ {
+			if !placed[i] && predecessors[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var s string
+			for i, proc := range processors {
+				if placed[i] {
+					continue
+				}
+				if s != "" {
+					s += ", "
+				}
+				if seq, ok := proc.(Sequencer); ok && seq.Label() != "" {
+					s += strconv.Quote(seq.Label())
+				} else {
+					s += "#" + strconv.Itoa(i+1)
+				}
+			}
+			return nil, kerror.Newf(kerror.EAmbiguous,
+				"%s processors have contradictory order constraints: %s", processors[0].Type(), s)
+		}
+		placed[next] = true
+		ordered = append(ordered, processors[next])
+		for _, j := range successors[next] {
+			predecessors[j]--
+		}
+	}
+	return ordered, nil
+}
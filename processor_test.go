@@ -1,6 +1,12 @@
 package kinit
 
-import "reflect"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
 
 // func(T, ...) error
 
@@ -68,3 +74,116 @@ func (testProcessorWithBrokenParameters) Parameters() []reflect.Type {
 func (testProcessorWithBrokenParameters) Process(obj reflect.Value, a ...reflect.Value) error {
 	return nil
 }
+
+type testSequencedProcessor struct {
+	*testProcessor
+	label string
+	after []string
+}
+
+func newTestSequencedProcessor(label string, after []string, x interface{}) *testSequencedProcessor {
+	return &testSequencedProcessor{newTestProcessor(x), label, after}
+}
+
+func (p *testSequencedProcessor) Label() string {
+	return p.label
+}
+
+func (p *testSequencedProcessor) After() []string {
+	return p.after
+}
+
+func TestSequence(t *testing.T) {
+	proc1 := newTestSequencedProcessor("loops", []string{"metrics", "config"}, func(int) error { return nil })
+	proc2 := newTestProcessor(func(int) error { return nil })
+	proc3 := newTestSequencedProcessor("metrics", []string{"config", "absent"}, func(int) error { return nil })
+	proc4 := newTestSequencedProcessor("config", nil, func(int) error { return nil })
+	processors, err := Sequence([]Processor{proc1, proc2, proc3, proc4})
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if len(processors) != 4 || processors[0] != proc2 || processors[1] != proc4 ||
+		processors[2] != proc3 || processors[3] != proc1 {
+		t.Fail()
+		return
+	}
+}
+
+func TestSequence__Unconstrained(t *testing.T) {
+	proc1 := newTestProcessor(func(int) error { return nil })
+	proc2 := newTestProcessor(func(int) error { return nil })
+	processors, err := Sequence([]Processor{proc1, proc2})
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if len(processors) != 2 || processors[0] != proc1 || processors[1] != proc2 {
+		t.Fail()
+		return
+	}
+}
+
+func TestSequence__AmbiguousLabel(t *testing.T) {
+	_, err := Sequence([]Processor{
+		newTestSequencedProcessor("config", nil, func(int) error { return nil }),
+		newTestSequencedProcessor("config", nil, func(int) error { return nil }),
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestSequence__ContradictoryConstraints(t *testing.T) {
+	_, err := Sequence([]Processor{
+		newTestSequencedProcessor("config", nil, func(int) error { return nil }),
+		newTestSequencedProcessor("metrics", []string{"loops"}, func(int) error { return nil }),
+		newTestSequencedProcessor("loops", []string{"metrics"}, func(int) error { return nil }),
+	})
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__SequencedProcessors(t *testing.T) {
+	var calls []string
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (int, kdone.Destructor, error) { return 0, kdone.Noop, nil }))
+	ctr.MustAttach(newTestSequencedProcessor("loops", []string{"metrics"}, func(int) error {
+		calls = append(calls, "loops")
+		return nil
+	}))
+	ctr.MustAttach(newTestSequencedProcessor("metrics", []string{"config"}, func(int) error {
+		calls = append(calls, "metrics")
+		return nil
+	}))
+	ctr.MustAttach(newTestSequencedProcessor("config", nil, func(int) error {
+		calls = append(calls, "config")
+		return nil
+	}))
+	ctr.MustRun(newTestFunctor(func(int) ([]Functor, error) { return nil, nil }))
+	if len(calls) != 3 || calls[0] != "config" || calls[1] != "metrics" || calls[2] != "loops" {
+		t.Logf("%v", calls)
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__ContradictoryProcessors(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (int, kdone.Destructor, error) { return 0, kdone.Noop, nil }))
+	ctr.MustAttach(newTestSequencedProcessor("metrics", []string{"loops"}, func(int) error { return nil }))
+	ctr.MustAttach(newTestSequencedProcessor("loops", []string{"metrics"}, func(int) error { return nil }))
+	err := ctr.Run(newTestFunctor(func(int) ([]Functor, error) { return nil, nil }))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
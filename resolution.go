@@ -0,0 +1,134 @@
+package kinit
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// resolution represents a state of the dependency resolution branch.
+type resolution struct {
+	// ctx specifies the context of the run this resolution is performed within.
+	ctx context.Context
+	// arena specifies the arena resolved objects are registered on.
+	arena *Arena
+	// t specifies the type which dependencies are resolved by this branch (nil for the root).
+	t reflect.Type
+	// parent specifies the branch this one was started from.
+	parent *resolution
+	// owner specifies the constructor, processor or functor which dependencies are resolved
+	// by this branch (nil if unknown).
+	owner interface{}
+	// parallel specifies whether to resolve dependencies concurrently.
+	parallel bool
+	// tracer specifies the tracer to report to (nil means no tracing).
+	tracer Tracer
+	// ended specifies the flag that is set when the creation of the object of the type ends
+	// (nil for the root).
+	ended *int32
+}
+
+// newResolution returns a new root resolution branch.
+func newResolution(ctx context.Context, arena *Arena, parallel bool, tracer Tracer) *resolution {
+	return &resolution{
+		ctx:      ctx,
+		arena:    arena,
+		parallel: parallel,
+		tracer:   tracer,
+	}
+}
+
+// branch returns a new branch started from this one to resolve dependencies of the given type
+// which objects are registered on the given arena.
+func (r *resolution) branch(t reflect.Type, arena *Arena) *resolution {
+	return &resolution{
+		ctx:      r.ctx,
+		arena:    arena,
+		t:        t,
+		parent:   r,
+		parallel: r.parallel,
+		tracer:   r.tracer,
+		ended:    new(int32),
+	}
+}
+
+// end marks this branch as the one which creation of the object of the type has ended.
+func (r *resolution) end() {
+	if r.ended != nil {
+		atomic.StoreInt32(r.ended, 1)
+	}
+}
+
+// active returns boolean specifies whether the creation of the object of the type of this branch
+// has not ended yet.
+func (r *resolution) active() bool {
+	return r.t != nil && (r.ended == nil || atomic.LoadInt32(r.ended) == 0)
+}
+
+// by returns a copy of this branch that resolves dependencies of the given owner
+// (constructor, processor or functor).
+func (r *resolution) by(owner interface{}) *resolution {
+	b := *r
+	b.owner = owner
+	return &b
+}
+
+// requirer returns the string representation of the type and the owner which dependencies are resolved
+// by this branch in parentheses prefixed by a space or the empty string if both are unknown.
+func (r *resolution) requirer() string {
+	if r == nil {
+		return ""
+	}
+	var parts []string
+	if r.t != nil {
+		parts = append(parts, r.t.String())
+	}
+	if s := Describe(r.owner).String(); s != "" {
+		parts = append(parts, s)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (required by " + strings.Join(parts, " ") + ")"
+}
+
+// resolving returns boolean specifies whether dependencies of the given type
+// are resolved by this branch or by the one it was started from.
+//
+// Branches which creations have ended are not taken into account, since runtimes and lazy dependencies
+// may outlive the resolution they were obtained by.
+func (r *resolution) resolving(t reflect.Type) bool {
+	for b := r; b != nil; b = b.parent {
+		if b.t == t && b.active() {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleTypes returns types forming the dependency cycle closed by the given type starting from it.
+func (r *resolution) cycleTypes(t reflect.Type) []reflect.Type {
+	types := []reflect.Type{t}
+	for b := r; b != nil && !(b.t == t && b.active()); b = b.parent {
+		if b.t == nil {
+			continue
+		}
+		types = append(types, nil)
+		copy(types[2:], types[1:])
+		types[1] = b.t
+	}
+	return types
+}
+
+// cycle returns the string representation of the dependency cycle closed by the given type.
+func (r *resolution) cycle(t reflect.Type) string {
+	s := t.String()
+	for b := r; b != nil && !(b.t == t && b.active()); b = b.parent {
+		if b.t == nil {
+			continue
+		}
+		s = b.t.String() + " 🠖 " + s
+	}
+	return t.String() + " 🠖 " + s
+}
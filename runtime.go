@@ -1,6 +1,7 @@
 package kinit
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/go-kata/kdone"
@@ -13,8 +14,13 @@ type Runtime struct {
 	container *Container
 	// arena specifies the arena associated with this runtime.
 	arena *Arena
+	// res specifies the resolution branch this runtime was obtained by (nil if unknown).
+	res *resolution
 }
 
+// runtimeType specifies the reflection to the runtime pointer type.
+var runtimeType = reflect.TypeOf((*Runtime)(nil))
+
 // NewRuntime returns a new runtime associated with given container and arena.
 func NewRuntime(ctr *Container, arena *Arena) (*Runtime, error) {
 	if ctr == nil {
@@ -29,6 +35,15 @@ func NewRuntime(ctr *Container, arena *Arena) (*Runtime, error) {
 	}, nil
 }
 
+// within returns a copy of this runtime bound to the given resolution branch.
+func (r *Runtime) within(res *resolution) *Runtime {
+	return &Runtime{
+		container: r.container,
+		arena:     r.arena,
+		res:       res,
+	}
+}
+
 // MustNewRuntime is a variant of the NewRuntime that panics on error.
 func MustNewRuntime(ctr *Container, arena *Arena) *Runtime {
 	r, err := NewRuntime(ctr, arena)
@@ -53,13 +68,93 @@ func (r *Runtime) MustPut(t reflect.Type, obj reflect.Value, dtor kdone.Destruct
 	}
 }
 
+// Resolve returns the object of the given type using the associated container.
+// If the object is already on the associated arena, it will be used. Otherwise it will be firstly created
+// and processed like a dependency of a functor and registered on the associated arena (or on the one
+// matching its scope), thus it will be destroyed along with the arena.
+//
+// If this runtime was passed to the constructor of an object the resolved one depends on,
+// the dependency cycle error will be returned.
+func (r *Runtime) Resolve(t reflect.Type) (reflect.Value, error) {
+	if r == nil {
+		return reflect.Value{}, kerror.New(kerror.ENil, "nil runtime cannot resolve object")
+	}
+	return r.container.resolve(contextOf(r.arena), r.arena, r.res, t)
+}
+
+// MustResolve is a variant of the Resolve that panics on error.
+func (r *Runtime) MustResolve(t reflect.Type) reflect.Value {
+	obj, err := r.Resolve(t)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
 // Run runs given functors using the associated container.
 // The created separate arena will use the associated arena as a parent.
-func (r *Runtime) Run(functors ...Functor) (err error) {
+//
+// The context of the run the associated arena belongs to will be used.
+//
+// If this runtime was passed to the constructor of an object functors depend on,
+// the dependency cycle error will be returned.
+func (r *Runtime) Run(functors ...Functor) error {
+	if r == nil {
+		return kerror.New(kerror.ENil, "nil runtime cannot run functors")
+	}
+	return r.RunContext(contextOf(r.arena), functors...)
+}
+
+// MustRun is a variant of Run that panics on error.
+func (r *Runtime) MustRun(functors ...Functor) {
+	if err := r.Run(functors...); err != nil {
+		panic(err)
+	}
+}
+
+// RunContext runs given functors like the Run does but with the given context.
+func (r *Runtime) RunContext(ctx context.Context, functors ...Functor) error {
 	if r == nil {
 		return kerror.New(kerror.ENil, "nil runtime cannot run functors")
 	}
-	arena := NewArena(r.arena)
+	if ctx == nil {
+		return kerror.New(kerror.EInvalid, "runtime cannot run functors with nil context")
+	}
+	return r.run(ctx, "", functors)
+}
+
+// MustRunContext is a variant of RunContext that panics on error.
+func (r *Runtime) MustRunContext(ctx context.Context, functors ...Functor) {
+	if err := r.RunContext(ctx, functors...); err != nil {
+		panic(err)
+	}
+}
+
+// RunScoped runs given functors like the Run does but within the given named scope:
+// objects of this scope (see the Scoper) will be registered on the created arena
+// and destroyed when the run ends.
+//
+// Neither the zero scope nor the Singleton or Transient scopes may be given.
+func (r *Runtime) RunScoped(scope Scope, functors ...Functor) error {
+	if r == nil {
+		return kerror.New(kerror.ENil, "nil runtime cannot run functors")
+	}
+	if scope == "" || scope == Singleton || scope == Transient {
+		return kerror.Newf(kerror.EInvalid, "runtime cannot run functors within %q scope", scope)
+	}
+	return r.run(contextOf(r.arena), scope, functors)
+}
+
+// MustRunScoped is a variant of RunScoped that panics on error.
+func (r *Runtime) MustRunScoped(scope Scope, functors ...Functor) {
+	if err := r.RunScoped(scope, functors...); err != nil {
+		panic(err)
+	}
+}
+
+// run runs given functors using the given context within the given scope.
+func (r *Runtime) run(ctx context.Context, scope Scope, functors []Functor) (err error) {
+	arena := r.container.newArena(scope, r.arena)
 	defer func() {
 		err = kerror.Join(err, arena.Finalize())
 	}()
@@ -70,12 +165,8 @@ func (r *Runtime) Run(functors ...Functor) (err error) {
 	if err := arena.Put(reflect.TypeOf(runtime), reflect.ValueOf(runtime), kdone.Noop); err != nil {
 		return err
 	}
-	return r.container.run(arena, functors)
-}
-
-// MustRun is a variant of Run that panics on error.
-func (r *Runtime) MustRun(functors ...Functor) {
-	if err := r.Run(functors...); err != nil {
-		panic(err)
+	if err := putContext(arena, ctx); err != nil {
+		return err
 	}
+	return r.container.run(ctx, arena, r.res, functors)
 }
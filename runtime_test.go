@@ -1,7 +1,10 @@
 package kinit
 
 import (
+	"context"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-kata/kdone"
@@ -42,6 +45,68 @@ func TestRuntime(t *testing.T) {
 	}))
 }
 
+func TestRuntime_Run__Concurrent(t *testing.T) {
+	var created int32
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (*int32, kdone.Destructor, error) {
+		atomic.AddInt32(&created, 1)
+		return new(int32), kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(shared *int32) (*int64, kdone.Destructor, error) {
+		atomic.AddInt32(shared, 1)
+		return new(int64), kdone.Noop, nil
+	}))
+	ctr.MustRun(newTestFunctor(func(runtime *Runtime, shared *int32) ([]Functor, error) {
+		errs := make([]error, 8)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = runtime.Run(newTestFunctor(func(*int64) ([]Functor, error) {
+					return nil, nil
+				}))
+			}(i)
+		}
+		wg.Wait()
+		if err := kerror.Join(errs...); err != nil {
+			return nil, err
+		}
+		if *shared != int32(len(errs)) {
+			return nil, kerror.Newf(kerror.EInvalid, "%d children expected, %d given", len(errs), *shared)
+		}
+		return nil, nil
+	}))
+	if created != 1 {
+		t.Logf("shared object was created %d times", created)
+		t.Fail()
+		return
+	}
+}
+
+func TestRuntime_RunContext(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustRunContext(context.Background(), newTestFunctor(func(runtime *Runtime, parentCtx context.Context) ([]Functor, error) {
+		if err := runtime.Run(newTestFunctor(func(ctx context.Context) ([]Functor, error) {
+			if ctx != parentCtx {
+				return nil, kerror.New(kerror.EInvalid, "parent context expected")
+			}
+			return nil, nil
+		})); err != nil {
+			return nil, err
+		}
+		childCtx, cancel := context.WithCancel(parentCtx)
+		cancel()
+		err := runtime.RunContext(childCtx, newTestFunctor(func() ([]Functor, error) {
+			return nil, nil
+		}))
+		if kerror.ClassOf(err) != kerror.ERuntime {
+			return nil, kerror.Newf(kerror.EInvalid, "interrupted run expected, %v given", err)
+		}
+		return nil, nil
+	}))
+}
+
 func TestNewRuntime__NilContainer(t *testing.T) {
 	_, err := NewRuntime(nil, NewArena())
 	t.Logf("%+v", err)
@@ -78,3 +143,140 @@ func TestNilRuntime_Run(t *testing.T) {
 		return
 	}
 }
+
+func TestNilRuntime_RunContext(t *testing.T) {
+	err := (*Runtime)(nil).RunContext(context.Background())
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestRuntime_Resolve(t *testing.T) {
+	var created, destroyed int
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func() (int32, kdone.Destructor, error) {
+		created++
+		return 1, kdone.DestructorFunc(func() error {
+			destroyed++
+			return nil
+		}), nil
+	}))
+	ctr.MustAttach(newTestProcessor(func(int32) error {
+		created++
+		return nil
+	}))
+	ctr.MustRun(newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+		obj, err := runtime.Resolve(reflect.TypeOf(int32(0)))
+		if err != nil {
+			return nil, err
+		}
+		if obj.Interface() != int32(1) {
+			t.Fail()
+		}
+		if again := runtime.MustResolve(reflect.TypeOf(int32(0))); again.Interface() != int32(1) {
+			t.Fail()
+		}
+		if created != 2 || destroyed != 0 {
+			t.Fail()
+		}
+		return nil, nil
+	}))
+	if destroyed != 1 {
+		t.Fail()
+		return
+	}
+}
+
+func TestRuntime_Resolve__NotFound(t *testing.T) {
+	err := NewContainer().Run(newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+		_, err := runtime.Resolve(reflect.TypeOf(int32(0)))
+		return nil, err
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+}
+
+func TestRuntime_Resolve__Cycle(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func(int64) (int32, kdone.Destructor, error) {
+		return 1, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(runtime *Runtime) (int64, kdone.Destructor, error) {
+		if _, err := runtime.Resolve(reflect.TypeOf(int32(0))); err != nil {
+			return 0, nil, err
+		}
+		return 2, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(int32) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestRuntime_Run__Cycle(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestConstructor(func(int64) (int32, kdone.Destructor, error) {
+		return 1, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(runtime *Runtime) (int64, kdone.Destructor, error) {
+		err := runtime.Run(newTestFunctor(func(int32) ([]Functor, error) {
+			return nil, nil
+		}))
+		if err != nil {
+			return 0, nil, err
+		}
+		return 2, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(int32) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EAmbiguous {
+		t.Fail()
+		return
+	}
+}
+
+func TestRuntime_Resolve__Retained(t *testing.T) {
+	var retained *Runtime
+	ctr := NewContainer()
+	ctr.MustProvide(newTestScopedConstructor(Singleton, func(runtime *Runtime) (*int64, kdone.Destructor, error) {
+		retained = runtime
+		return new(int64), kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(*int64) (*int32, kdone.Destructor, error) {
+		return new(int32), kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+		if err := runtime.Run(newTestFunctor(func(*int32) ([]Functor, error) {
+			return nil, nil
+		})); err != nil {
+			return nil, err
+		}
+		_, err := retained.Resolve(reflect.TypeOf((*int32)(nil)))
+		return nil, err
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestNilRuntime_Resolve(t *testing.T) {
+	_, err := (*Runtime)(nil).Resolve(reflect.TypeOf(int32(0)))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package kinit
+
+// Scope represents a lifetime of objects.
+//
+// Objects of the zero scope are registered on the arena of the run that requires them. Objects of the Singleton
+// scope are registered on the arena of the outermost run. Objects of the Transient scope are not registered at all:
+// a new object is created for each dependent. Objects of any other (named) scope are registered on the nearest
+// arena of that scope (see the Runtime.RunScoped), e.g. a scope of a request or a job.
+type Scope string
+
+const (
+	// Singleton specifies the scope of objects that live as long as the outermost run.
+	Singleton Scope = "singleton"
+	// Transient specifies the scope of objects that are created for each dependent and live as long as it does.
+	Transient Scope = "transient"
+)
+
+// Scoper represents an optional interface of a constructor that creates objects of the certain scope.
+type Scoper interface {
+	// Scope returns the scope of objects created by this constructor.
+	Scope() Scope
+}
+
+// ScopeOf returns the scope of objects created by the given constructor if it implements the Scoper interface.
+// Otherwise the zero scope will be returned.
+func ScopeOf(ctor Constructor) Scope {
+	if s, ok := ctor.(Scoper); ok {
+		return s.Scope()
+	}
+	return ""
+}
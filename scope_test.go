@@ -0,0 +1,247 @@
+package kinit
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+type testScopedConstructor struct {
+	*testConstructor
+	scope Scope
+}
+
+func newTestScopedConstructor(scope Scope, x interface{}) *testScopedConstructor {
+	return &testScopedConstructor{
+		testConstructor: newTestConstructor(x),
+		scope:           scope,
+	}
+}
+
+func (c *testScopedConstructor) Scope() Scope {
+	return c.scope
+}
+
+type testSession struct {
+	id int
+}
+
+func TestContainer_Run__Scopes(t *testing.T) {
+	var singletons, sessions, destroyedSessions int
+	ctr := NewContainer()
+	ctr.MustProvide(newTestScopedConstructor(Singleton, func() (*int32, kdone.Destructor, error) {
+		singletons++
+		return new(int32), kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestScopedConstructor("request", func(_ *int32) (*testSession, kdone.Destructor, error) {
+		sessions++
+		return &testSession{id: sessions}, kdone.DestructorFunc(func() error {
+			destroyedSessions++
+			return nil
+		}), nil
+	}))
+	handle := func(id int) Functor {
+		return newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+			return nil, runtime.Run(newTestFunctor(func(session *testSession) ([]Functor, error) {
+				if session.id != id {
+					return nil, kerror.Newf(kerror.EInvalid, "session: %d expected, %d given", id, session.id)
+				}
+				return nil, nil
+			}))
+		})
+	}
+	err := ctr.Run(newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+		for id := 1; id <= 2; id++ {
+			if err := runtime.RunScoped("request", handle(id), handle(id)); err != nil {
+				return nil, err
+			}
+			if destroyedSessions != id {
+				return nil, kerror.Newf(kerror.EInvalid, "destroyed sessions: %d expected, %d given",
+					id, destroyedSessions)
+			}
+		}
+		return nil, nil
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if singletons != 1 || sessions != 2 {
+		t.Logf("singletons: %d, sessions: %d", singletons, sessions)
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__ScopeMismatch(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestScopedConstructor("request", func() (*testSession, kdone.Destructor, error) {
+		return &testSession{}, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(session *testSession) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EIllegal {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__CaptiveDependency(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestScopedConstructor("request", func() (*testSession, kdone.Destructor, error) {
+		return &testSession{}, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestScopedConstructor(Singleton, func(_ *testSession) (*int32, kdone.Destructor, error) {
+		return new(int32), kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+		return nil, runtime.RunScoped("request", newTestFunctor(func(_ *int32) ([]Functor, error) {
+			return nil, nil
+		}))
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EIllegal {
+		t.Fail()
+		return
+	}
+}
+
+func TestRuntime_RunScoped__Singleton(t *testing.T) {
+	arena := NewArena()
+	defer arena.MustFinalize()
+	runtime := MustNewRuntime(NewContainer(), arena)
+	err := runtime.RunScoped(Singleton)
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.EInvalid {
+		t.Fail()
+		return
+	}
+}
+
+func TestNilRuntime_RunScoped(t *testing.T) {
+	err := (*Runtime)(nil).RunScoped("request")
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENil {
+		t.Fail()
+		return
+	}
+}
+
+func TestScopeOf(t *testing.T) {
+	if ScopeOf(newTestScopedConstructor("request", func() (*testSession, kdone.Destructor, error) {
+		return nil, nil, nil
+	})) != "request" {
+		t.Fail()
+		return
+	}
+	if ScopeOf(testConstructorWithBrokenType{}) != "" {
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__Transient(t *testing.T) {
+	var created, destroyed int
+	ctr := NewContainer()
+	ctr.MustProvide(newTestScopedConstructor(Transient, func() (*testSession, kdone.Destructor, error) {
+		created++
+		return &testSession{id: created}, kdone.DestructorFunc(func() error {
+			destroyed++
+			return nil
+		}), nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(session *testSession) (*int32, kdone.Destructor, error) {
+		return new(int32), kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(runtime *Runtime, s1, s2 *testSession, _ *int32) ([]Functor, error) {
+		if s1 == s2 {
+			return nil, kerror.New(kerror.EInvalid, "transient object is shared")
+		}
+		s3, err := runtime.Resolve(reflect.TypeOf(s1))
+		if err != nil {
+			return nil, err
+		}
+		if s3.Interface() == s1 || s3.Interface() == s2 {
+			return nil, kerror.New(kerror.EInvalid, "transient object is shared")
+		}
+		return nil, nil
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+	if created != 4 || destroyed != created {
+		t.Logf("created: %d, destroyed: %d", created, destroyed)
+		t.Fail()
+		return
+	}
+}
+
+type testContextStarter struct {
+	ctx context.Context
+}
+
+func (s *testContextStarter) Start(ctx context.Context) error {
+	s.ctx = ctx
+	return nil
+}
+
+func TestContainer_Run__StartContext(t *testing.T) {
+	ctr := NewContainer()
+	ctr.MustProvide(newTestScopedConstructor(Singleton, func(ctx context.Context) (*testContextStarter, kdone.Destructor, error) {
+		if ctx.Value(testContextKey{}) != "root" {
+			return nil, nil, kerror.New(kerror.EInvalid, "root context expected")
+		}
+		return &testContextStarter{}, kdone.Noop, nil
+	}))
+	ctx := context.WithValue(context.Background(), testContextKey{}, "root")
+	err := ctr.RunContext(ctx, newTestFunctor(func(runtime *Runtime) ([]Functor, error) {
+		ctx := context.WithValue(context.Background(), testContextKey{}, "child")
+		return nil, runtime.RunContext(ctx, newTestFunctor(func(s *testContextStarter) ([]Functor, error) {
+			if s.ctx.Value(testContextKey{}) != "root" {
+				return nil, kerror.Newf(kerror.EInvalid, "root context expected, %v given", s.ctx.Value(testContextKey{}))
+			}
+			return nil, nil
+		}))
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__LazyTransient(t *testing.T) {
+	var created int
+	ctr := NewContainer()
+	ctr.MustProvide(newTestScopedConstructor(Transient, func() (*testSession, kdone.Destructor, error) {
+		created++
+		return &testSession{id: created}, kdone.Noop, nil
+	}))
+	err := ctr.Run(newTestFunctor(func(lazy func() (*testSession, error)) ([]Functor, error) {
+		s1, err := lazy()
+		if err != nil {
+			return nil, err
+		}
+		s2, err := lazy()
+		if err != nil {
+			return nil, err
+		}
+		if s1 == s2 {
+			return nil, kerror.New(kerror.EInvalid, "transient object is shared")
+		}
+		return nil, nil
+	}))
+	if err != nil {
+		t.Logf("%+v", err)
+		t.Fail()
+		return
+	}
+}
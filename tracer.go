@@ -0,0 +1,46 @@
+package kinit
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/go-kata/kdone"
+)
+
+// Tracer represents a set of hooks the container calls while running functors.
+//
+// In the parallel mode hooks may be called from several goroutines simultaneously,
+// thus the tracer must be safe for concurrent use in this mode.
+type Tracer interface {
+	// OnResolveStart is called when the creation of an object of the given type begins.
+	OnResolveStart(t reflect.Type)
+	// OnResolveEnd is called when the creation of an object of the given type ends.
+	//
+	// The creation includes the resolution of dependencies, the processing and the start of an object.
+	// The constructor is nil for groups and types without constructor.
+	OnResolveEnd(t reflect.Type, ctor Constructor, d time.Duration, err error)
+	// OnProcess is called when the given processor has processed an object of the given type.
+	//
+	// The processing includes the resolution of processor dependencies.
+	OnProcess(t reflect.Type, proc Processor, d time.Duration, err error)
+	// OnDestroy is called when an object of the given type has been destroyed.
+	OnDestroy(t reflect.Type, d time.Duration, err error)
+	// OnFunctorCall is called when the given functor has been called.
+	//
+	// The call doesn't include the resolution of functor dependencies.
+	OnFunctorCall(fun Functor, d time.Duration, err error)
+}
+
+// traceDestructor returns the given destructor of an object of the given type
+// that reports the destruction to the given tracer.
+func traceDestructor(tracer Tracer, t reflect.Type, dtor kdone.Destructor) kdone.Destructor {
+	if tracer == nil || dtor == nil {
+		return dtor
+	}
+	return kdone.DestructorFunc(func() error {
+		start := time.Now()
+		err := dtor.Destroy()
+		tracer.OnDestroy(t, time.Since(start), err)
+		return err
+	})
+}
@@ -0,0 +1,105 @@
+package kinit
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kata/kdone"
+	"github.com/go-kata/kerror"
+)
+
+type testTracer struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (t *testTracer) record(event string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *testTracer) OnResolveStart(typ reflect.Type) {
+	t.record("start " + typ.String())
+}
+
+func (t *testTracer) OnResolveEnd(typ reflect.Type, ctor Constructor, d time.Duration, err error) {
+	if ctor == nil || err != nil {
+		t.record("fail " + typ.String())
+		return
+	}
+	t.record("end " + typ.String())
+}
+
+func (t *testTracer) OnProcess(typ reflect.Type, proc Processor, d time.Duration, err error) {
+	t.record("process " + typ.String())
+}
+
+func (t *testTracer) OnDestroy(typ reflect.Type, d time.Duration, err error) {
+	t.record("destroy " + typ.String())
+}
+
+func (t *testTracer) OnFunctorCall(fun Functor, d time.Duration, err error) {
+	t.record("call")
+}
+
+func TestContainer_Run__Tracer(t *testing.T) {
+	tracer := &testTracer{}
+	ctr := NewContainer()
+	ctr.SetTracer(tracer)
+	ctr.MustProvide(newTestConstructor(func() (int, kdone.Destructor, error) {
+		return 1, kdone.Noop, nil
+	}))
+	ctr.MustProvide(newTestConstructor(func(int) (string, kdone.Destructor, error) {
+		return "", kdone.Noop, nil
+	}))
+	ctr.MustAttach(newTestProcessor(func(int) error { return nil }))
+	ctr.MustRun(newTestFunctor(func(string) ([]Functor, error) {
+		return nil, nil
+	}))
+	expected := []string{
+		"start string",
+		"start int",
+		"process int",
+		"end int",
+		"end string",
+		"call",
+		"destroy string",
+		"destroy int",
+	}
+	if !reflect.DeepEqual(tracer.events, expected) {
+		t.Logf("%v", tracer.events)
+		t.Fail()
+		return
+	}
+}
+
+func TestContainer_Run__TracerWithUnsatisfiedDependency(t *testing.T) {
+	tracer := &testTracer{}
+	ctr := NewContainer()
+	ctr.SetTracer(tracer)
+	err := ctr.Run(newTestFunctor(func(int) ([]Functor, error) {
+		return nil, nil
+	}))
+	t.Logf("%+v", err)
+	if kerror.ClassOf(err) != kerror.ENotFound {
+		t.Fail()
+		return
+	}
+	expected := []string{"start int", "fail int"}
+	if !reflect.DeepEqual(tracer.events, expected) {
+		t.Logf("%v", tracer.events)
+		t.Fail()
+		return
+	}
+}
+
+func TestNilContainer_SetTracer(t *testing.T) {
+	(*Container)(nil).SetTracer(&testTracer{})
+	if (*Container)(nil).Tracer() != nil {
+		t.Fail()
+		return
+	}
+}